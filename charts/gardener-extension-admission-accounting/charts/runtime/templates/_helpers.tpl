@@ -0,0 +1,23 @@
+{{- define "name" -}}
+gardener-extension-admission-fits-accounting
+{{- end -}}
+
+{{- define "labels.app.key" -}}
+app.kubernetes.io/name
+{{- end -}}
+{{- define "labels.app.value" -}}
+{{ include "name" . }}
+{{- end -}}
+
+{{- define "labels" -}}
+{{ include "labels.app.key" . }}: {{ include "labels.app.value" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+
+{{- define "leaderelectionid" -}}
+admission-fits-accounting-leader-election
+{{- end -}}
+
+{{- define "gardenNamespace" -}}
+{{ .Values.global.gardenNamespace | default .Release.Namespace }}
+{{- end -}}
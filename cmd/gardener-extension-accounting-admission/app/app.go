@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	admissioncmd "github.com/fi-ts/gardener-extension-accounting/pkg/admission/cmd"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/admission/validator"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/install"
+
+	controllercmd "github.com/gardener/gardener/extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener/extensions/pkg/util"
+	webhookcmd "github.com/gardener/gardener/extensions/pkg/webhook/cmd"
+	gardencoreinstall "github.com/gardener/gardener/pkg/apis/core/install"
+	gardenerhealthz "github.com/gardener/gardener/pkg/healthz"
+
+	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// AdmissionName is the name of the admission component.
+const AdmissionName = "admission-fits-accounting"
+
+var log = runtimelog.Log.WithName("gardener-extension-accounting-admission")
+
+// NewAdmissionCommand creates a new command for running the accounting admission webhook.
+func NewAdmissionCommand(ctx context.Context) *cobra.Command {
+	var (
+		restOpts = &controllercmd.RESTOptions{}
+		mgrOpts  = &controllercmd.ManagerOptions{
+			LeaderElection:          true,
+			LeaderElectionID:        controllercmd.LeaderElectionNameID(AdmissionName),
+			LeaderElectionNamespace: os.Getenv("LEADER_ELECTION_NAMESPACE"),
+			WebhookServerPort:       443,
+			WebhookCertDir:          "/tmp/admission-fits-accounting-cert",
+			MetricsBindAddress:      ":8080",
+			HealthBindAddress:       ":8081",
+		}
+		// options for the webhook server
+		webhookServerOptions = &webhookcmd.ServerOptions{
+			Namespace: os.Getenv("WEBHOOK_CONFIG_NAMESPACE"),
+		}
+		validatorOpts   = &admissioncmd.ValidatorOptions{}
+		webhookSwitches = admissioncmd.GardenWebhookSwitchOptions()
+		webhookOptions  = webhookcmd.NewAddToManagerOptions(
+			AdmissionName,
+			"",
+			nil,
+			webhookServerOptions,
+			webhookSwitches,
+		)
+
+		aggOption = controllercmd.NewOptionAggregator(
+			restOpts,
+			mgrOpts,
+			validatorOpts,
+			webhookOptions,
+		)
+	)
+
+	cmd := &cobra.Command{
+		Use:           "gardener-extension-accounting-admission",
+		Short:         "validates the fits-accounting provider config of shoots.",
+		SilenceErrors: true,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if gardenKubeconfig := os.Getenv("GARDEN_KUBECONFIG"); gardenKubeconfig != "" {
+				log.Info("Getting rest config for garden from GARDEN_KUBECONFIG", "path", gardenKubeconfig)
+				restOpts.Kubeconfig = gardenKubeconfig
+			}
+
+			if err := aggOption.Complete(); err != nil {
+				return fmt.Errorf("error completing options: %w", err)
+			}
+
+			cmd.SilenceUsage = true
+
+			util.ApplyClientConnectionConfigurationToRESTConfig(&componentbaseconfigv1alpha1.ClientConnectionConfiguration{
+				QPS:   100.0,
+				Burst: 130,
+			}, restOpts.Completed().Config)
+
+			mgr, err := manager.New(restOpts.Completed().Config, mgrOpts.Completed().Options())
+			if err != nil {
+				return fmt.Errorf("could not instantiate manager: %w", err)
+			}
+
+			gardencoreinstall.Install(mgr.GetScheme())
+
+			if err := install.AddToScheme(mgr.GetScheme()); err != nil {
+				return fmt.Errorf("could not update manager scheme: %w", err)
+			}
+
+			validatorOpts.Completed().Apply(&validator.DefaultAddOptions)
+
+			log.Info("Setting up webhook server")
+			if _, err := webhookOptions.Completed().AddToManager(ctx, mgr, nil, false); err != nil {
+				return fmt.Errorf("could not add webhooks to manager: %w", err)
+			}
+
+			if err := mgr.AddReadyzCheck("informer-sync", gardenerhealthz.NewCacheSyncHealthz(mgr.GetCache())); err != nil {
+				return fmt.Errorf("could not add ready check for informers: %w", err)
+			}
+
+			if err := mgr.AddReadyzCheck("webhook-server", mgr.GetWebhookServer().StartedChecker()); err != nil {
+				return fmt.Errorf("could not add ready check for webhook server: %w", err)
+			}
+
+			if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+				return fmt.Errorf("could not add health check to manager: %w", err)
+			}
+
+			if err := mgr.Start(ctx); err != nil {
+				return fmt.Errorf("error running manager: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	aggOption.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+
+	"github.com/fi-ts/gardener-extension-accounting/cmd/gardener-extension-accounting-admission/app"
+	"github.com/gardener/gardener/pkg/logger"
+
+	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+func main() {
+	runtimelog.SetLogger(logger.MustNewZapLogger(logger.InfoLevel, logger.FormatJSON))
+	cmd := app.NewAdmissionCommand(signals.SetupSignalHandler())
+
+	if err := cmd.Execute(); err != nil {
+		runtimelog.Log.Error(err, "error executing the main admission command")
+		os.Exit(1)
+	}
+}
@@ -7,10 +7,10 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/install"
 	"github.com/fi-ts/gardener-extension-accounting/pkg/controller"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/controller/cwnp"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/controller/healthcheck"
 
-	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	heartbeatcontroller "github.com/gardener/gardener/extensions/pkg/controller/heartbeat"
 	"github.com/gardener/gardener/extensions/pkg/util"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
@@ -20,18 +20,10 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/intstr"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-
-	firewallv2 "github.com/metal-stack/firewall-controller/v2/api/v1"
 	corev1 "k8s.io/api/core/v1"
-	networkingv1 "k8s.io/api/networking/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const GardenKubeconfigEnvName = "GARDEN_KUBECONFIG"
@@ -87,11 +79,7 @@ func (o *Options) run(ctx context.Context) error {
 		return fmt.Errorf("could not instantiate controller-manager: %w", err)
 	}
 
-	if err := extensionscontroller.AddToScheme(mgr.GetScheme()); err != nil {
-		return fmt.Errorf("could not update manager scheme: %w", err)
-	}
-
-	if err := install.AddToScheme(mgr.GetScheme()); err != nil {
+	if err := controller.AddToScheme(mgr.GetScheme()); err != nil {
 		return fmt.Errorf("could not update manager scheme: %w", err)
 	}
 
@@ -120,9 +108,15 @@ func (o *Options) run(ctx context.Context) error {
 
 	ctrlConfig := o.accountingOptions.Completed()
 	ctrlConfig.Apply(&controller.DefaultAddOptions.Config)
+	ctrlConfig.Apply(&cwnp.DefaultAddOptions.Config)
+	ctrlConfig.ApplyHealthCheckConfig(&healthcheck.DefaultAddOptions.HealthCheckConfig)
 	o.controllerOptions.Completed().Apply(&controller.DefaultAddOptions.ControllerOptions)
+	o.controllerOptions.Completed().Apply(&cwnp.DefaultAddOptions.ControllerOptions)
+	o.healthOptions.Completed().Apply(&healthcheck.DefaultAddOptions.Controller)
 	o.reconcileOptions.Completed().Apply(&controller.DefaultAddOptions.IgnoreOperationAnnotation, &controller.DefaultAddOptions.ExtensionClass)
+	o.reconcileOptions.Completed().Apply(nil, &healthcheck.DefaultAddOptions.ExtensionClass)
 	o.heartbeatOptions.Completed().Apply(&heartbeatcontroller.DefaultAddOptions)
+	controller.DefaultAddOptions.GardenCluster = gardenCluster
 
 	if err := o.controllerSwitches.Completed().AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("could not add controllers to manager: %w", err)
@@ -136,61 +130,9 @@ func (o *Options) run(ctx context.Context) error {
 		return fmt.Errorf("could not add health check to manager: %w", err)
 	}
 
-	if err := deployAccountingCWNP(ctx, mgr); err != nil {
-		return err
-	}
-
 	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("error running manager: %w", err)
 	}
 
 	return nil
 }
-
-func deployAccountingCWNP(ctx context.Context, mgr manager.Manager) error {
-	scheme := runtime.NewScheme()
-	utilruntime.Must(firewallv2.AddToScheme(scheme))
-
-	c, err := client.New(mgr.GetConfig(), client.Options{
-		Scheme: scheme,
-	})
-	if err != nil {
-		return fmt.Errorf("unable to create client: %w", err)
-	}
-
-	cp := &firewallv2.ClusterwideNetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "egress-allow-accounting-api",
-			Namespace: "firewall",
-		},
-	}
-
-	_, err = controllerutil.CreateOrUpdate(ctx, c, cp, func() error {
-		port9000 := intstr.FromInt(9000)
-		tcp := corev1.ProtocolTCP
-
-		cp.Spec.Egress = []firewallv2.EgressRule{
-			{
-				Ports: []networkingv1.NetworkPolicyPort{
-					{
-						Port:     &port9000,
-						Protocol: &tcp,
-					},
-				},
-				To: []networkingv1.IPBlock{
-					{
-						CIDR: "0.0.0.0/0",
-					},
-				},
-			},
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("unable to deploy clusterwide network policy for accounting-api into seed firewall namespace %w", err)
-	}
-
-	return nil
-}
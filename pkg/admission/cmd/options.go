@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	webhookcmd "github.com/gardener/gardener/extensions/pkg/webhook/cmd"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/admission/validator"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/validation"
+)
+
+// GardenWebhookSwitchOptions are the webhookcmd.SwitchOptions for the admission webhooks.
+func GardenWebhookSwitchOptions() *webhookcmd.SwitchOptions {
+	return webhookcmd.NewSwitchOptions(
+		webhookcmd.Switch(validator.Name, validator.New),
+	)
+}
+
+// ValidatorOptions holds the options of the validation of the accounting provider config of the shoots.
+type ValidatorOptions struct {
+	// MaxExporterCPU is the maximum cpu request and limit of the accounting-exporter of a shoot.
+	MaxExporterCPU string
+	// MaxExporterMemory is the maximum memory request and limit of the accounting-exporter of a shoot.
+	MaxExporterMemory string
+
+	config *ValidatorConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (o *ValidatorOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.MaxExporterCPU, "max-exporter-cpu", "1", "Maximum cpu request and limit of the accounting-exporter a shoot may configure, should match exporterMaxResources of the extension")
+	fs.StringVar(&o.MaxExporterMemory, "max-exporter-memory", "4Gi", "Maximum memory request and limit of the accounting-exporter a shoot may configure, should match exporterMaxResources of the extension")
+}
+
+// Complete implements Completer.Complete.
+func (o *ValidatorOptions) Complete() error {
+	maxResources := corev1.ResourceList{}
+
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    o.MaxExporterCPU,
+		corev1.ResourceMemory: o.MaxExporterMemory,
+	} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("unable to parse maximum %s of the accounting-exporter: %w", name, err)
+		}
+		maxResources[name] = quantity
+	}
+
+	if errs := validation.ValidateMaxResources(maxResources, field.NewPath("maxExporterResources")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	o.config = &ValidatorConfig{
+		MaxExporterResources: maxResources,
+	}
+
+	return nil
+}
+
+// Completed returns the completed ValidatorConfig. Only call this if `Complete` was successful.
+func (o *ValidatorOptions) Completed() *ValidatorConfig {
+	return o.config
+}
+
+// ValidatorConfig is the completed configuration of the validation of the accounting provider config of the shoots.
+type ValidatorConfig struct {
+	// MaxExporterResources are the maximum requests and limits of the accounting-exporter of a shoot.
+	MaxExporterResources corev1.ResourceList
+}
+
+// Apply applies the ValidatorConfig to the passed AddOptions of the validator.
+func (c *ValidatorConfig) Apply(opts *validator.AddOptions) {
+	opts.MaxExporterResources = c.MaxExporterResources
+}
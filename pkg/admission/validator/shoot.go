@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/validation"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/constants"
+)
+
+type shoot struct {
+	decoder              runtime.Decoder
+	maxExporterResources corev1.ResourceList
+}
+
+// NewShootValidator returns a new instance of a shoot validator, which rejects accounting-exporter resources exceeding the given maximum.
+func NewShootValidator(mgr manager.Manager, maxExporterResources corev1.ResourceList) extensionswebhook.Validator {
+	return &shoot{
+		decoder:              serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		maxExporterResources: maxExporterResources,
+	}
+}
+
+// Validate validates the accounting provider config of the given shoot.
+func (s *shoot) Validate(_ context.Context, newObj, _ client.Object) error {
+	shoot, ok := newObj.(*core.Shoot)
+	if !ok {
+		return fmt.Errorf("wrong object type %T", newObj)
+	}
+
+	if shoot.DeletionTimestamp != nil {
+		return nil
+	}
+
+	for i, ext := range shoot.Spec.Extensions {
+		if ext.Type != constants.ExtensionType {
+			continue
+		}
+
+		if ext.Disabled != nil && *ext.Disabled {
+			return nil
+		}
+
+		if ext.ProviderConfig == nil {
+			return nil
+		}
+
+		fldPath := field.NewPath("spec", "extensions").Index(i).Child("providerConfig")
+
+		accountingConfig := &accounting.AccountingConfig{}
+		if err := runtime.DecodeInto(s.decoder, ext.ProviderConfig.Raw, accountingConfig); err != nil {
+			return field.Invalid(fldPath, string(ext.ProviderConfig.Raw), fmt.Sprintf("failed to decode provider config: %s", err))
+		}
+
+		allErrs := validation.ValidateAccountingConfig(accountingConfig, fldPath)
+		if accountingConfig.ExporterResources != nil {
+			allErrs = append(allErrs, validation.ValidateResourceRequirementsWithin(accountingConfig.ExporterResources, s.maxExporterResources, fldPath.Child("exporterResources"))...)
+		}
+
+		return allErrs.ToAggregate()
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/install"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/constants"
+)
+
+func TestShootValidate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := install.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to create scheme: %s", err)
+	}
+
+	validator := &shoot{
+		decoder:              serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+		maxExporterResources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+
+	providerConfig := func(raw string) *runtime.RawExtension {
+		return &runtime.RawExtension{Raw: []byte(raw)}
+	}
+
+	tests := []struct {
+		name       string
+		extensions []core.Extension
+		deleted    bool
+		wantErr    bool
+	}{
+		{
+			name: "shoot without the extension",
+		},
+		{
+			name:       "extension without provider config",
+			extensions: []core.Extension{{Type: constants.ExtensionType}},
+		},
+		{
+			name: "valid provider config",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","networkTrafficEnabled":false}`),
+			}},
+		},
+		{
+			name: "invalid provider config",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","networkTrafficEnabled":"no"}`),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "options unknown to the accounting-exporter are rejected",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","costCenter":"4711"}`),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "exporter resources up to the maximum",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","exporterResources":{"limits":{"memory":"4Gi"}}}`),
+			}},
+		},
+		{
+			name: "exporter resources exceeding the maximum",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","exporterResources":{"limits":{"memory":"64Gi"}}}`),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "unknown field in provider config",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","costcenter":"4711"}`),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "provider config of another extension",
+			extensions: []core.Extension{{
+				Type:           "shoot-dns-service",
+				ProviderConfig: providerConfig(`{"apiVersion":"service.dns.extensions.gardener.cloud/v1alpha1","kind":"DNSConfig"}`),
+			}},
+		},
+		{
+			name: "disabled extension",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				Disabled:       pointer.Pointer(true),
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","networkTrafficEnabled":"no"}`),
+			}},
+		},
+		{
+			name: "deleted shoot",
+			extensions: []core.Extension{{
+				Type:           constants.ExtensionType,
+				ProviderConfig: providerConfig(`{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingConfig","networkTrafficEnabled":"no"}`),
+			}},
+			deleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoot := &core.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "garden-test"},
+				Spec: core.ShootSpec{
+					Extensions: tt.extensions,
+				},
+			}
+			if tt.deleted {
+				shoot.DeletionTimestamp = pointer.Pointer(metav1.Now())
+			}
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/apis/core"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/constants"
+)
+
+const (
+	// Name is a name for a validation webhook.
+	Name = "validator"
+)
+
+var (
+	logger = log.Log.WithName("fits-accounting-validator-webhook")
+
+	// DefaultAddOptions are the default AddOptions for New.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the validation webhook.
+type AddOptions struct {
+	// MaxExporterResources are the maximum requests and limits of the accounting-exporter a shoot may configure.
+	MaxExporterResources corev1.ResourceList
+}
+
+// New creates a new webhook that validates Shoot resources.
+func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
+	logger.Info("Setting up webhook", "name", Name)
+
+	return extensionswebhook.New(mgr, extensionswebhook.Args{
+		Provider: constants.ExtensionType,
+		Name:     Name,
+		Path:     "/webhooks/validate",
+		Validators: map[extensionswebhook.Validator][]extensionswebhook.Type{
+			NewShootValidator(mgr, DefaultAddOptions.MaxExporterResources): {{Obj: &core.Shoot{}}},
+		},
+		Target: extensionswebhook.TargetSeed,
+		ObjectSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{v1beta1constants.LabelExtensionExtensionTypePrefix + constants.ExtensionType: "true"},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -9,4 +10,39 @@ import (
 // AccountingConfig configuration resource
 type AccountingConfig struct {
 	metav1.TypeMeta
+
+	// NetworkTrafficEnabled enables the accounting of the network traffic of the cluster.
+	NetworkTrafficEnabled *bool
+	// ExporterResources override the resource requirements of the accounting-exporter of the cluster per resource name.
+	ExporterResources *corev1.ResourceRequirements
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccountingStatus contains the status of the accounting of a cluster.
+type AccountingStatus struct {
+	metav1.TypeMeta
+
+	// TenantID is the tenant of the cluster reported by the accounting-exporter.
+	TenantID string
+	// ProjectID is the project of the cluster reported by the accounting-exporter.
+	ProjectID string
+	// ProjectName is the name of the project reported by the accounting-exporter.
+	ProjectName string
+	// MetalAPI is the name of the metal-api the project was resolved from.
+	MetalAPI string
+	// Dimensions are the additional accounting dimensions taken from the project.
+	Dimensions map[string]string
+	// ExporterImage is the image of the deployed accounting-exporter.
+	ExporterImage string
+	// AccountingAPIEndpoint is the endpoint of the accounting-api the accounting-exporter reports to.
+	AccountingAPIEndpoint string
+	// LastSuccessfulReconcileTime is the time of the last successful reconciliation.
+	LastSuccessfulReconcileTime *metav1.Time
+	// Hibernated is whether the cluster is hibernated, the accounting-exporter is scaled down then.
+	Hibernated bool
+	// LastHibernationTransitionTime is the time the cluster was hibernated or woke up the last time.
+	LastHibernationTransitionTime *metav1.Time
+	// HibernationTransitionPending is whether the cluster woke up and the scaled up accounting-exporter is not available yet.
+	HibernationTransitionPending bool
 }
@@ -1,9 +1,17 @@
 package v1alpha1
 
 import (
+	"github.com/metal-stack/metal-lib/pkg/pointer"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_AccountingConfig sets the defaults for the accounting config of a shoot.
+func SetDefaults_AccountingConfig(obj *AccountingConfig) {
+	if obj.NetworkTrafficEnabled == nil {
+		obj.NetworkTrafficEnabled = pointer.Pointer(true)
+	}
+}
@@ -0,0 +1,37 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+)
+
+func TestSetObjectDefaultsAccountingConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *AccountingConfig
+		want *AccountingConfig
+	}{
+		{
+			name: "network traffic is accounted by default",
+			cfg:  &AccountingConfig{},
+			want: &AccountingConfig{NetworkTrafficEnabled: pointer.Pointer(true)},
+		},
+		{
+			name: "disabled network traffic accounting is kept",
+			cfg:  &AccountingConfig{NetworkTrafficEnabled: pointer.Pointer(false)},
+			want: &AccountingConfig{NetworkTrafficEnabled: pointer.Pointer(false)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetObjectDefaults_AccountingConfig(tt.cfg)
+
+			if diff := cmp.Diff(tt.want, tt.cfg); diff != "" {
+				t.Errorf("SetObjectDefaults_AccountingConfig() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
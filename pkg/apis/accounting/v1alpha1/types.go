@@ -1,12 +1,15 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	SeedAccountingResourceName  = "extension-fits-accounting"
 	ShootAccountingResourceName = "extension-fits-accounting-shoot"
+
+	AccountingExporterName = "accounting-exporter"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -14,4 +17,52 @@ const (
 // AccountingConfig configuration resource
 type AccountingConfig struct {
 	metav1.TypeMeta `json:",inline"`
+
+	// NetworkTrafficEnabled enables the accounting of the network traffic of the cluster.
+	// +optional
+	NetworkTrafficEnabled *bool `json:"networkTrafficEnabled,omitempty"`
+	// ExporterResources override the resource requirements of the accounting-exporter of the cluster per resource name.
+	// +optional
+	ExporterResources *corev1.ResourceRequirements `json:"exporterResources,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccountingStatus contains the status of the accounting of a cluster.
+type AccountingStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// TenantID is the tenant of the cluster reported by the accounting-exporter.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+	// ProjectID is the project of the cluster reported by the accounting-exporter.
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+	// ProjectName is the name of the project reported by the accounting-exporter.
+	// +optional
+	ProjectName string `json:"projectName,omitempty"`
+	// MetalAPI is the name of the metal-api the project was resolved from.
+	// +optional
+	MetalAPI string `json:"metalAPI,omitempty"`
+	// Dimensions are the additional accounting dimensions taken from the project.
+	// +optional
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	// ExporterImage is the image of the deployed accounting-exporter.
+	// +optional
+	ExporterImage string `json:"exporterImage,omitempty"`
+	// AccountingAPIEndpoint is the endpoint of the accounting-api the accounting-exporter reports to.
+	// +optional
+	AccountingAPIEndpoint string `json:"accountingAPIEndpoint,omitempty"`
+	// LastSuccessfulReconcileTime is the time of the last successful reconciliation.
+	// +optional
+	LastSuccessfulReconcileTime *metav1.Time `json:"lastSuccessfulReconcileTime,omitempty"`
+	// Hibernated is whether the cluster is hibernated, the accounting-exporter is scaled down then.
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
+	// LastHibernationTransitionTime is the time the cluster was hibernated or woke up the last time.
+	// +optional
+	LastHibernationTransitionTime *metav1.Time `json:"lastHibernationTransitionTime,omitempty"`
+	// HibernationTransitionPending is whether the cluster woke up and the scaled up accounting-exporter is not available yet.
+	// +optional
+	HibernationTransitionPending bool `json:"hibernationTransitionPending,omitempty"`
 }
@@ -10,7 +10,11 @@
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	accounting "github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -32,10 +36,22 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AccountingStatus)(nil), (*accounting.AccountingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AccountingStatus_To_accounting_AccountingStatus(a.(*AccountingStatus), b.(*accounting.AccountingStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*accounting.AccountingStatus)(nil), (*AccountingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_accounting_AccountingStatus_To_v1alpha1_AccountingStatus(a.(*accounting.AccountingStatus), b.(*AccountingStatus), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
 func autoConvert_v1alpha1_AccountingConfig_To_accounting_AccountingConfig(in *AccountingConfig, out *accounting.AccountingConfig, s conversion.Scope) error {
+	out.NetworkTrafficEnabled = (*bool)(unsafe.Pointer(in.NetworkTrafficEnabled))
+	out.ExporterResources = (*v1.ResourceRequirements)(unsafe.Pointer(in.ExporterResources))
 	return nil
 }
 
@@ -45,6 +61,8 @@ func Convert_v1alpha1_AccountingConfig_To_accounting_AccountingConfig(in *Accoun
 }
 
 func autoConvert_accounting_AccountingConfig_To_v1alpha1_AccountingConfig(in *accounting.AccountingConfig, out *AccountingConfig, s conversion.Scope) error {
+	out.NetworkTrafficEnabled = (*bool)(unsafe.Pointer(in.NetworkTrafficEnabled))
+	out.ExporterResources = (*v1.ResourceRequirements)(unsafe.Pointer(in.ExporterResources))
 	return nil
 }
 
@@ -52,3 +70,43 @@ func autoConvert_accounting_AccountingConfig_To_v1alpha1_AccountingConfig(in *ac
 func Convert_accounting_AccountingConfig_To_v1alpha1_AccountingConfig(in *accounting.AccountingConfig, out *AccountingConfig, s conversion.Scope) error {
 	return autoConvert_accounting_AccountingConfig_To_v1alpha1_AccountingConfig(in, out, s)
 }
+
+func autoConvert_v1alpha1_AccountingStatus_To_accounting_AccountingStatus(in *AccountingStatus, out *accounting.AccountingStatus, s conversion.Scope) error {
+	out.TenantID = in.TenantID
+	out.ProjectID = in.ProjectID
+	out.ProjectName = in.ProjectName
+	out.MetalAPI = in.MetalAPI
+	out.Dimensions = *(*map[string]string)(unsafe.Pointer(&in.Dimensions))
+	out.ExporterImage = in.ExporterImage
+	out.AccountingAPIEndpoint = in.AccountingAPIEndpoint
+	out.LastSuccessfulReconcileTime = (*metav1.Time)(unsafe.Pointer(in.LastSuccessfulReconcileTime))
+	out.Hibernated = in.Hibernated
+	out.LastHibernationTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastHibernationTransitionTime))
+	out.HibernationTransitionPending = in.HibernationTransitionPending
+	return nil
+}
+
+// Convert_v1alpha1_AccountingStatus_To_accounting_AccountingStatus is an autogenerated conversion function.
+func Convert_v1alpha1_AccountingStatus_To_accounting_AccountingStatus(in *AccountingStatus, out *accounting.AccountingStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AccountingStatus_To_accounting_AccountingStatus(in, out, s)
+}
+
+func autoConvert_accounting_AccountingStatus_To_v1alpha1_AccountingStatus(in *accounting.AccountingStatus, out *AccountingStatus, s conversion.Scope) error {
+	out.TenantID = in.TenantID
+	out.ProjectID = in.ProjectID
+	out.ProjectName = in.ProjectName
+	out.MetalAPI = in.MetalAPI
+	out.Dimensions = *(*map[string]string)(unsafe.Pointer(&in.Dimensions))
+	out.ExporterImage = in.ExporterImage
+	out.AccountingAPIEndpoint = in.AccountingAPIEndpoint
+	out.LastSuccessfulReconcileTime = (*metav1.Time)(unsafe.Pointer(in.LastSuccessfulReconcileTime))
+	out.Hibernated = in.Hibernated
+	out.LastHibernationTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastHibernationTransitionTime))
+	out.HibernationTransitionPending = in.HibernationTransitionPending
+	return nil
+}
+
+// Convert_accounting_AccountingStatus_To_v1alpha1_AccountingStatus is an autogenerated conversion function.
+func Convert_accounting_AccountingStatus_To_v1alpha1_AccountingStatus(in *accounting.AccountingStatus, out *AccountingStatus, s conversion.Scope) error {
+	return autoConvert_accounting_AccountingStatus_To_v1alpha1_AccountingStatus(in, out, s)
+}
@@ -17,5 +17,10 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&AccountingConfig{}, func(obj interface{}) { SetObjectDefaults_AccountingConfig(obj.(*AccountingConfig)) })
 	return nil
 }
+
+func SetObjectDefaults_AccountingConfig(in *AccountingConfig) {
+	SetDefaults_AccountingConfig(in)
+}
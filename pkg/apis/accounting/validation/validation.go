@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting"
+)
+
+var supportedResourceNames = sets.New(corev1.ResourceCPU, corev1.ResourceMemory)
+
+// ValidateAccountingConfig validates the passed accounting config of a shoot.
+func ValidateAccountingConfig(cfg *accounting.AccountingConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.ExporterResources != nil {
+		allErrs = append(allErrs, ValidateResourceRequirements(cfg.ExporterResources, fldPath.Child("exporterResources"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateMaxResources validates the maximum resources of the accounting-exporter.
+func ValidateMaxResources(maxResources corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, quantity := range maxResources {
+		if !supportedResourceNames.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Key(string(name)), name, sets.List(supportedResourceNames)))
+		}
+		if quantity.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(name)), quantity.String(), "maximum must be positive"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateResourceRequirementsWithin ensures that the requests and limits of the accounting-exporter do not exceed the given maximum resources.
+func ValidateResourceRequirementsWithin(resources *corev1.ResourceRequirements, maxResources corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, kind := range []struct {
+		name string
+		list corev1.ResourceList
+	}{
+		{name: "limits", list: resources.Limits},
+		{name: "requests", list: resources.Requests},
+	} {
+		for name, quantity := range kind.list {
+			if maximum, ok := maxResources[name]; ok && quantity.Cmp(maximum) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(kind.name).Key(string(name)), quantity.String(), fmt.Sprintf("must not be greater than the maximum %s", maximum.String())))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateResourceRequirements validates the resource requirements of the accounting-exporter.
+func ValidateResourceRequirements(resources *corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, quantity := range resources.Limits {
+		limitPath := fldPath.Child("limits").Key(string(name))
+
+		if !supportedResourceNames.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(limitPath, name, sets.List(supportedResourceNames)))
+		}
+		if quantity.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath, quantity.String(), "limit must be positive"))
+		}
+	}
+
+	for name, quantity := range resources.Requests {
+		requestPath := fldPath.Child("requests").Key(string(name))
+
+		if !supportedResourceNames.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(requestPath, name, sets.List(supportedResourceNames)))
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(requestPath, quantity.String(), "request must not be negative"))
+		}
+		if limit, ok := resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(requestPath, quantity.String(), fmt.Sprintf("request must not be greater than the limit %s", limit.String())))
+		}
+	}
+
+	return allErrs
+}
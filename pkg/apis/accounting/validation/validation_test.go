@@ -0,0 +1,214 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/validation"
+)
+
+// fieldError is the part of a field.Error which is compared by the tests.
+type fieldError struct {
+	Type  field.ErrorType
+	Field string
+}
+
+func fieldErrors(errs field.ErrorList) []fieldError {
+	var result []fieldError
+	for _, err := range errs {
+		result = append(result, fieldError{Type: err.Type, Field: err.Field})
+	}
+	return result
+}
+
+func TestValidateAccountingConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *accounting.AccountingConfig
+		want []fieldError
+	}{
+		{
+			name: "empty config",
+			cfg:  &accounting.AccountingConfig{},
+		},
+		{
+			name: "valid config",
+			cfg: &accounting.AccountingConfig{
+				NetworkTrafficEnabled: pointer.Pointer(false),
+				ExporterResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("100Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		},
+		{
+			name: "invalid exporter resources",
+			cfg: &accounting.AccountingConfig{
+				ExporterResources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("0")},
+				},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "spec.exporterResources.limits[memory]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldErrors(validation.ValidateAccountingConfig(tt.cfg, field.NewPath("spec")))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateAccountingConfig() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateResourceRequirements(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *corev1.ResourceRequirements
+		want      []fieldError
+	}{
+		{
+			name: "requests below the limits",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+		},
+		{
+			name: "unsupported resource name",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeNotSupported, Field: "resources.requests[ephemeral-storage]"},
+			},
+		},
+		{
+			name: "negative request",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "resources.requests[cpu]"},
+			},
+		},
+		{
+			name: "request greater than the limit",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "resources.requests[memory]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldErrors(validation.ValidateResourceRequirements(tt.resources, field.NewPath("resources")))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateResourceRequirements() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMaxResources(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxResources corev1.ResourceList
+		want         []fieldError
+	}{
+		{
+			name:         "cpu and memory",
+			maxResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+		{
+			name: "no maximum",
+		},
+		{
+			name:         "unsupported resource name",
+			maxResources: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+			want: []fieldError{
+				{Type: field.ErrorTypeNotSupported, Field: "max[ephemeral-storage]"},
+			},
+		},
+		{
+			name:         "zero maximum",
+			maxResources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("0")},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "max[memory]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldErrors(validation.ValidateMaxResources(tt.maxResources, field.NewPath("max")))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateMaxResources() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateResourceRequirementsWithin(t *testing.T) {
+	maxResources := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}
+
+	tests := []struct {
+		name      string
+		resources *corev1.ResourceRequirements
+		want      []fieldError
+	}{
+		{
+			name: "resources up to the maximum",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			name: "resources without a maximum are not bounded",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16")},
+			},
+		},
+		{
+			name: "request greater than the maximum",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "resources.requests[memory]"},
+			},
+		},
+		{
+			name: "limit greater than the maximum",
+			resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")},
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "resources.limits[memory]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fieldErrors(validation.ValidateResourceRequirementsWithin(tt.resources, maxResources, field.NewPath("resources")))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateResourceRequirementsWithin() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
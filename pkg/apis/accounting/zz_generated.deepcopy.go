@@ -10,6 +10,7 @@
 package accounting
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -17,6 +18,16 @@ import (
 func (in *AccountingConfig) DeepCopyInto(out *AccountingConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.NetworkTrafficEnabled != nil {
+		in, out := &in.NetworkTrafficEnabled, &out.NetworkTrafficEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExporterResources != nil {
+		in, out := &in.ExporterResources, &out.ExporterResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -37,3 +48,43 @@ func (in *AccountingConfig) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSuccessfulReconcileTime != nil {
+		in, out := &in.LastSuccessfulReconcileTime, &out.LastSuccessfulReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHibernationTransitionTime != nil {
+		in, out := &in.LastHibernationTransitionTime, &out.LastHibernationTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingStatus.
+func (in *AccountingStatus) DeepCopy() *AccountingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountingStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
@@ -20,13 +21,66 @@ type ControllerConfiguration struct {
 
 	// ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
 	ImagePullSecret *ImagePullSecret
+
+	// ClusterwideNetworkPolicy configures the policy allowing the egress traffic from the seed to the accounting-api through the firewall
+	ClusterwideNetworkPolicy ClusterwideNetworkPolicy
+
+	// NetworkPolicy configures the network policy restricting the traffic of the accounting-exporter in the shoot namespaces
+	NetworkPolicy NetworkPolicy
+
+	// Monitoring configures the scraping of and the alerting for the accounting-exporters
+	Monitoring Monitoring
+}
+
+// Monitoring configures the scraping of and the alerting for the accounting-exporters by the prometheus of the shoots.
+type Monitoring struct {
+	// Enabled deploys a service monitor and the alerting rules for every accounting-exporter
+	Enabled *bool
+	// LastEventSentMetric is the metric of the accounting-exporter containing the unix timestamp of the last event sent to the accounting-api
+	LastEventSentMetric string
+	// NoEventsSentAlertThreshold is the duration without events sent to the accounting-api after which an alert is fired
+	NoEventsSentAlertThreshold *metav1.Duration
+}
+
+// NetworkPolicy configures the network policy restricting the traffic of the accounting-exporter in the shoot namespaces.
+type NetworkPolicy struct {
+	// Enabled deploys a network policy for every accounting-exporter, which only allows the egress traffic to the accounting-api
+	// and the kube-apiserver of the shoot and the ingress traffic from the prometheus of the shoot.
+	Enabled bool
+	// CIDRs are the destinations of the allowed egress traffic to the accounting-api. If empty, the addresses of the accounting-api host are resolved.
+	CIDRs []string
+	// SyncPeriod is the interval in which the extensions are reconciled to resolve the accounting-api host again, if no CIDRs are configured
+	SyncPeriod *metav1.Duration
+}
+
+// ClusterwideNetworkPolicy configures the policy allowing the egress traffic from the seed to the accounting-api through the firewall.
+type ClusterwideNetworkPolicy struct {
+	// Namespace is the namespace of the policy
+	Namespace string
+	// CIDRs are the destinations of the allowed egress traffic. If empty, the addresses of the accounting-api host are resolved.
+	CIDRs []string
+	// SyncPeriod is the interval in which the policy is reconciled and the accounting-api host is resolved again
+	SyncPeriod *metav1.Duration
 }
 
 // Accounting contains the configuration for fi-ts specific accounting in the cluster.
 type Accounting struct {
-	MetalURL      string
-	MetalHMAC     string
+	MetalURL string
+	// MetalHMAC is the hmac used for the metal-api
+	MetalHMAC string
+	// MetalAuthType is the hmac auth type used for the metal-api
 	MetalAuthType string
+	// MetalAuth authenticates at the metal-api with credentials read from files, e.g. a service account token or a client certificate
+	MetalAuth *MetalAPIAuth
+	// MetalAPIs are further metal-apis, which are selected for the shoots by their region or their cloud profile.
+	// The shoots which do not match any of them use the metal-api configured above.
+	MetalAPIs []MetalAPI
+	// ProjectCacheTTL is the duration after which the projects fetched from the metal-api are refreshed
+	ProjectCacheTTL *metav1.Duration
+	// ProjectNotFoundCacheTTL is the duration for which a project that does not exist in the metal-api is not looked up again
+	ProjectNotFoundCacheTTL *metav1.Duration
+	// ProjectCacheStaleWhileError serves expired projects from the cache when the metal-api cannot be reached
+	ProjectCacheStaleWhileError *bool
 
 	// AccountingHost the host domain to reach the accounting-api
 	AccountingHost string
@@ -38,6 +92,161 @@ type Accounting struct {
 	ClientCert string
 	// ClientKey is the client key certificate to communicate with the accounting-api
 	ClientKey string
+	// ClientCA issues a client certificate for every shoot to communicate with the accounting-api.
+	// If it is set, ClientCert and ClientKey are not used.
+	ClientCA *ClientCA
+	// Partitions maps partition ids to the accounting-api the accounting-exporters of the shoots in the partition report to.
+	// The shoots of all other partitions report to the accounting-api configured above.
+	Partitions map[string]AccountingEndpoint
+
+	// ExporterPort is the port on which the accounting-exporter serves its health endpoint
+	ExporterPort int32
+	// ExporterResources are the resource requirements of the accounting-exporter, they can be overridden per shoot
+	ExporterResources *corev1.ResourceRequirements
+	// ExporterMaxResources are the maximum requests and limits of the accounting-exporter per resource name
+	ExporterMaxResources corev1.ResourceList
+	// ExporterVPA configures the vertical pod autoscaler of the accounting-exporter
+	ExporterVPA ExporterVPA
+	// ExporterAvailability configures the scheduling and the disruptions of the accounting-exporter
+	ExporterAvailability ExporterAvailability
+
+	// ProjectResolver configures where the tenant and the name of a shoot's project are looked up
+	ProjectResolver ProjectResolver
+
+	// CredentialsSource references the credentials of the accounting instead of configuring them inline.
+	// The referenced credentials are reloaded when they change.
+	CredentialsSource *CredentialsSource
+}
+
+// DefaultMetalAPIName is the name of the metal-api configured by the metal url and the metal hmac of the accounting configuration.
+const DefaultMetalAPIName = "default"
+
+// MetalAPI is a metal-api, in which the projects of the shoots in its regions or with its cloud profiles are looked up.
+type MetalAPI struct {
+	// Name identifies the metal-api in the status and the metrics
+	Name string
+	// URL is the url of the metal-api
+	URL string
+	// HMAC is the hmac used for the metal-api
+	HMAC string
+	// AuthType is the hmac auth type used for the metal-api
+	AuthType string
+	// Auth authenticates at the metal-api with credentials read from files, e.g. a service account token or a client certificate
+	Auth *MetalAPIAuth
+	// Regions selects the metal-api for the shoots in these regions
+	Regions []string
+	// CloudProfiles selects the metal-api for the shoots with these cloud profiles
+	CloudProfiles []string
+}
+
+// MetalAPIAuth authenticates at a metal-api with credentials read from mounted files. The files are read whenever
+// a client for the metal-api is created, such that rotated credentials are picked up.
+type MetalAPIAuth struct {
+	// TokenFile is a file containing a bearer token, e.g. a projected service account token
+	TokenFile string
+	// ClientCertFile is a file containing a client certificate for mutual tls
+	ClientCertFile string
+	// ClientKeyFile is a file containing the private key of the client certificate
+	ClientKeyFile string
+	// CAFile is a file containing the ca certificates to verify the metal-api, the system roots are used if empty
+	CAFile string
+}
+
+// AccountingEndpoint is an accounting-api instance. The settings which are not configured are taken from the default accounting-api.
+type AccountingEndpoint struct {
+	// AccountingHost the host domain to reach the accounting-api
+	AccountingHost string
+	// AccountingPort the port to reach the accounting-api
+	AccountingPort string
+	// CA is the ca certificate of the accounting-api
+	CA string
+	// ClientCert is the client certificate to communicate with the accounting-api, it is shared by all shoots of the partition.
+	// If it is not set, the client certificate of the default accounting-api is used.
+	ClientCert string
+	// ClientKey is the client key certificate to communicate with the accounting-api, it is shared by all shoots of the partition
+	ClientKey string
+}
+
+// ExporterVPA configures the vertical pod autoscaler of the accounting-exporter.
+// The requests of the accounting-exporter are the lower bound of the recommendations.
+type ExporterVPA struct {
+	// Enabled deploys a vertical pod autoscaler for every accounting-exporter
+	Enabled *bool
+	// MaxAllowed is the upper bound of the recommendations
+	MaxAllowed corev1.ResourceList
+}
+
+// ExporterAvailability configures the scheduling and the disruptions of the accounting-exporter in the seed.
+type ExporterAvailability struct {
+	// PriorityClassName is the priority class of the accounting-exporter
+	PriorityClassName string
+	// PodDisruptionBudget deploys a pod disruption budget for every accounting-exporter, which allows one unavailable pod like the ones of gardener
+	PodDisruptionBudget *bool
+	// HighAvailability applies the high availability settings of gardener to the accounting-exporter if the control plane
+	// of the shoot is highly available.
+	HighAvailability *bool
+}
+
+// CredentialsSource references the metal-api hmac, the accounting-api ca and the client certificates.
+// The credentials are read from the keys metalHMAC, ca, cert, key, clientCACert and clientCAKey.
+type CredentialsSource struct {
+	// SecretRef references a secret containing the credentials.
+	SecretRef *corev1.SecretReference
+	// Path is a directory containing the credentials as files, e.g. a mounted secret.
+	Path *string
+}
+
+// ClientCA is the certificate authority issuing the client certificates of the accounting-exporters.
+type ClientCA struct {
+	// Certificate is the ca certificate
+	Certificate string
+	// PrivateKey is the private key of the ca certificate
+	PrivateKey string
+	// Validity is the validity of the issued client certificates, they are renewed before they expire
+	Validity *metav1.Duration
+}
+
+// ProjectResolverType is the type of a project resolver.
+type ProjectResolverType string
+
+const (
+	// ProjectResolverTypeMetal looks up the projects in the metal-api.
+	ProjectResolverTypeMetal ProjectResolverType = "metal"
+	// ProjectResolverTypeGarden reads the project metadata from annotations of the shoot and its project in the garden cluster.
+	ProjectResolverTypeGarden ProjectResolverType = "garden"
+	// ProjectResolverTypeStatic reads the project metadata from a static mapping in this configuration.
+	ProjectResolverTypeStatic ProjectResolverType = "static"
+)
+
+// ProjectResolver configures where the tenant and the name of a shoot's project are looked up.
+type ProjectResolver struct {
+	// Type is the type of the project resolver
+	Type ProjectResolverType
+	// ProviderTypes maps the provider types of the shoots to the project resolver of their shoots, the shoots of the other
+	// provider types use the project resolver of the type. The metal project resolver only resolves shoots of the provider type metal,
+	// the shoots of the other provider types fall back to the garden project resolver.
+	ProviderTypes map[string]ProjectResolverType
+	// Static maps project ids to their metadata, only used by the static project resolver
+	Static map[string]StaticProject
+	// Dimensions are the labels and annotations of the metal projects which are reported as additional accounting
+	// dimensions in the accounting status, only used by the metal project resolver
+	Dimensions []ProjectDimension
+}
+
+// ProjectDimension picks a label or an annotation of the metal project as an accounting dimension.
+type ProjectDimension struct {
+	// Key is the key of the label or the annotation of the project, annotations take precedence over labels
+	Key string
+	// Name is the name of the accounting dimension
+	Name string
+}
+
+// StaticProject contains the metadata of a project for the static project resolver.
+type StaticProject struct {
+	// Name is the name of the project
+	Name string
+	// TenantID is the id of the tenant the project belongs to
+	TenantID string
 }
 
 // ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
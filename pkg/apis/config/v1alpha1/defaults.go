@@ -1,9 +1,126 @@
 package v1alpha1
 
 import (
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_Accounting sets the defaults for the accounting configuration.
+func SetDefaults_Accounting(obj *Accounting) {
+	if obj.MetalAuthType == "" {
+		obj.MetalAuthType = "Metal-View"
+	}
+	if obj.ProjectCacheTTL == nil {
+		obj.ProjectCacheTTL = &metav1.Duration{Duration: 30 * time.Minute}
+	}
+	if obj.ProjectNotFoundCacheTTL == nil {
+		obj.ProjectNotFoundCacheTTL = &metav1.Duration{Duration: time.Minute}
+	}
+	if obj.ProjectCacheStaleWhileError == nil {
+		obj.ProjectCacheStaleWhileError = pointer.Pointer(true)
+	}
+	if obj.AccountingPort == "" {
+		obj.AccountingPort = "9000"
+	}
+	if obj.ExporterPort == 0 {
+		obj.ExporterPort = 3000
+	}
+	if obj.ExporterResources == nil {
+		obj.ExporterResources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		}
+	}
+	if obj.ExporterMaxResources == nil {
+		obj.ExporterMaxResources = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}
+	}
+}
+
+// SetDefaults_MetalAPI sets the defaults for a further metal-api.
+func SetDefaults_MetalAPI(obj *MetalAPI) {
+	if obj.AuthType == "" {
+		obj.AuthType = "Metal-View"
+	}
+}
+
+// SetDefaults_ExporterAvailability sets the defaults for the scheduling and the disruptions of the accounting-exporter.
+func SetDefaults_ExporterAvailability(obj *ExporterAvailability) {
+	if obj.PriorityClassName == "" {
+		obj.PriorityClassName = v1beta1constants.PriorityClassNameShootControlPlane200
+	}
+	if obj.PodDisruptionBudget == nil {
+		obj.PodDisruptionBudget = pointer.Pointer(true)
+	}
+	if obj.HighAvailability == nil {
+		obj.HighAvailability = pointer.Pointer(true)
+	}
+}
+
+// SetDefaults_ExporterVPA sets the defaults for the vertical pod autoscaler of the accounting-exporter.
+func SetDefaults_ExporterVPA(obj *ExporterVPA) {
+	if obj.Enabled == nil {
+		obj.Enabled = pointer.Pointer(true)
+	}
+}
+
+// SetDefaults_ClientCA sets the defaults for the client ca configuration.
+func SetDefaults_ClientCA(obj *ClientCA) {
+	if obj.Validity == nil {
+		obj.Validity = &metav1.Duration{Duration: 90 * 24 * time.Hour}
+	}
+}
+
+// SetDefaults_ClusterwideNetworkPolicy sets the defaults for the clusterwide network policy configuration.
+func SetDefaults_ClusterwideNetworkPolicy(obj *ClusterwideNetworkPolicy) {
+	if obj.Namespace == "" {
+		obj.Namespace = "firewall"
+	}
+	if obj.SyncPeriod == nil {
+		obj.SyncPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
+
+// SetDefaults_NetworkPolicy sets the defaults for the network policy configuration.
+func SetDefaults_NetworkPolicy(obj *NetworkPolicy) {
+	if obj.SyncPeriod == nil {
+		obj.SyncPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
+
+// SetDefaults_Monitoring sets the defaults for the monitoring configuration.
+func SetDefaults_Monitoring(obj *Monitoring) {
+	if obj.Enabled == nil {
+		obj.Enabled = pointer.Pointer(true)
+	}
+	if obj.LastEventSentMetric == "" {
+		obj.LastEventSentMetric = "accounting_exporter_last_event_sent_timestamp_seconds"
+	}
+	if obj.NoEventsSentAlertThreshold == nil {
+		obj.NoEventsSentAlertThreshold = &metav1.Duration{Duration: time.Hour}
+	}
+}
+
+// SetDefaults_ProjectResolver sets the defaults for the project resolver configuration.
+func SetDefaults_ProjectResolver(obj *ProjectResolver) {
+	if obj.Type == "" {
+		obj.Type = ProjectResolverTypeMetal
+	}
+}
@@ -0,0 +1,159 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetObjectDefaultsControllerConfiguration(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *ControllerConfiguration
+		want *ControllerConfiguration
+	}{
+		{
+			name: "empty configuration",
+			cfg:  &ControllerConfiguration{},
+			want: &ControllerConfiguration{
+				Accounting: Accounting{
+					MetalAuthType:               "Metal-View",
+					ProjectCacheTTL:             &metav1.Duration{Duration: 30 * time.Minute},
+					ProjectNotFoundCacheTTL:     &metav1.Duration{Duration: time.Minute},
+					ProjectCacheStaleWhileError: pointer.Pointer(true),
+					AccountingPort:              "9000",
+					ExporterPort:                3000,
+					ExporterResources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("50m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+					ExporterMaxResources: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+					ExporterVPA: ExporterVPA{
+						Enabled: pointer.Pointer(true),
+					},
+					ExporterAvailability: ExporterAvailability{
+						PriorityClassName:   v1beta1constants.PriorityClassNameShootControlPlane200,
+						PodDisruptionBudget: pointer.Pointer(true),
+						HighAvailability:    pointer.Pointer(true),
+					},
+					ProjectResolver: ProjectResolver{
+						Type: ProjectResolverTypeMetal,
+					},
+				},
+				ClusterwideNetworkPolicy: ClusterwideNetworkPolicy{
+					Namespace:  "firewall",
+					SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+				NetworkPolicy: NetworkPolicy{
+					SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+				Monitoring: Monitoring{
+					Enabled:                    pointer.Pointer(true),
+					LastEventSentMetric:        "accounting_exporter_last_event_sent_timestamp_seconds",
+					NoEventsSentAlertThreshold: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+		{
+			name: "configured values are kept",
+			cfg: &ControllerConfiguration{
+				Accounting: Accounting{
+					MetalAuthType:               "Metal-Admin",
+					ProjectCacheTTL:             &metav1.Duration{Duration: time.Hour},
+					ProjectNotFoundCacheTTL:     &metav1.Duration{Duration: 0},
+					ProjectCacheStaleWhileError: pointer.Pointer(false),
+					AccountingPort:              "443",
+					ExporterPort:                8080,
+					ExporterResources:           &corev1.ResourceRequirements{},
+					ExporterMaxResources:        corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					MetalAPIs:                   []MetalAPI{{Name: "region-b"}},
+					ClientCA:                    &ClientCA{},
+					ExporterVPA: ExporterVPA{
+						Enabled: pointer.Pointer(false),
+					},
+					ExporterAvailability: ExporterAvailability{
+						PriorityClassName:   "accounting",
+						PodDisruptionBudget: pointer.Pointer(false),
+						HighAvailability:    pointer.Pointer(false),
+					},
+					ProjectResolver: ProjectResolver{
+						Type: ProjectResolverTypeGarden,
+					},
+				},
+				ClusterwideNetworkPolicy: ClusterwideNetworkPolicy{
+					Namespace:  "egress",
+					SyncPeriod: &metav1.Duration{Duration: time.Minute},
+				},
+				NetworkPolicy: NetworkPolicy{
+					SyncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				Monitoring: Monitoring{
+					Enabled: pointer.Pointer(false),
+				},
+			},
+			want: &ControllerConfiguration{
+				Accounting: Accounting{
+					MetalAuthType:               "Metal-Admin",
+					ProjectCacheTTL:             &metav1.Duration{Duration: time.Hour},
+					ProjectNotFoundCacheTTL:     &metav1.Duration{Duration: 0},
+					ProjectCacheStaleWhileError: pointer.Pointer(false),
+					AccountingPort:              "443",
+					ExporterPort:                8080,
+					ExporterResources:           &corev1.ResourceRequirements{},
+					ExporterMaxResources:        corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					MetalAPIs:                   []MetalAPI{{Name: "region-b", AuthType: "Metal-View"}},
+					ClientCA: &ClientCA{
+						Validity: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+					},
+					ExporterVPA: ExporterVPA{
+						Enabled: pointer.Pointer(false),
+					},
+					ExporterAvailability: ExporterAvailability{
+						PriorityClassName:   "accounting",
+						PodDisruptionBudget: pointer.Pointer(false),
+						HighAvailability:    pointer.Pointer(false),
+					},
+					ProjectResolver: ProjectResolver{
+						Type: ProjectResolverTypeGarden,
+					},
+				},
+				ClusterwideNetworkPolicy: ClusterwideNetworkPolicy{
+					Namespace:  "egress",
+					SyncPeriod: &metav1.Duration{Duration: time.Minute},
+				},
+				NetworkPolicy: NetworkPolicy{
+					SyncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				Monitoring: Monitoring{
+					Enabled:                    pointer.Pointer(false),
+					LastEventSentMetric:        "accounting_exporter_last_event_sent_timestamp_seconds",
+					NoEventsSentAlertThreshold: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetObjectDefaults_ControllerConfiguration(tt.cfg)
+
+			if diff := cmp.Diff(tt.want, tt.cfg); diff != "" {
+				t.Errorf("SetObjectDefaults_ControllerConfiguration() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
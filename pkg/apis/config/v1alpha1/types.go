@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
@@ -21,24 +22,301 @@ type ControllerConfiguration struct {
 
 	// ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
 	ImagePullSecret *ImagePullSecret `json:"imagePullSecret,omitempty"`
+
+	// ClusterwideNetworkPolicy configures the policy allowing the egress traffic from the seed to the accounting-api through the firewall
+	// +optional
+	ClusterwideNetworkPolicy ClusterwideNetworkPolicy `json:"clusterwideNetworkPolicy,omitempty"`
+
+	// NetworkPolicy configures the network policy restricting the traffic of the accounting-exporter in the shoot namespaces
+	// +optional
+	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Monitoring configures the scraping of and the alerting for the accounting-exporters
+	// +optional
+	Monitoring Monitoring `json:"monitoring,omitempty"`
+}
+
+// Monitoring configures the scraping of and the alerting for the accounting-exporters by the prometheus of the shoots.
+type Monitoring struct {
+	// Enabled deploys a service monitor and the alerting rules for every accounting-exporter, defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// LastEventSentMetric is the metric of the accounting-exporter containing the unix timestamp of the last event sent to the accounting-api,
+	// defaults to accounting_exporter_last_event_sent_timestamp_seconds
+	// +optional
+	LastEventSentMetric string `json:"lastEventSentMetric,omitempty"`
+	// NoEventsSentAlertThreshold is the duration without events sent to the accounting-api after which an alert is fired, defaults to 1h
+	// +optional
+	NoEventsSentAlertThreshold *metav1.Duration `json:"noEventsSentAlertThreshold,omitempty"`
+}
+
+// NetworkPolicy configures the network policy restricting the traffic of the accounting-exporter in the shoot namespaces.
+type NetworkPolicy struct {
+	// Enabled deploys a network policy for every accounting-exporter, which only allows the egress traffic to the accounting-api
+	// and the kube-apiserver of the shoot and the ingress traffic from the prometheus of the shoot.
+	// Otherwise the accounting-exporter is allowed to reach all public networks. Defaults to false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// CIDRs are the destinations of the allowed egress traffic to the accounting-api. If empty, the addresses of the accounting-api host are resolved.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+	// SyncPeriod is the interval in which the extensions are reconciled to resolve the accounting-api host again,
+	// if no CIDRs are configured. Defaults to 5m.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// ClusterwideNetworkPolicy configures the policy allowing the egress traffic from the seed to the accounting-api through the firewall.
+// The policy is deployed by the cwnp controller, which can be disabled for seeds without the firewall-controller.
+type ClusterwideNetworkPolicy struct {
+	// Namespace is the namespace of the policy, defaults to firewall
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// CIDRs are the destinations of the allowed egress traffic. If empty, the addresses of the accounting-api host are resolved.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+	// SyncPeriod is the interval in which the policy is reconciled and the accounting-api host is resolved again, defaults to 5m
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
 }
 
 // Accounting contains the configuration for fi-ts specific accounting in the cluster.
 type Accounting struct {
-	MetalURL      string `json:"metalURL"`
-	MetalHMAC     string `json:"metalHMAC"`
-	MetalAuthType string `json:"metalAuthType"`
+	MetalURL string `json:"metalURL"`
+	// MetalHMAC is the hmac used for the metal-api, it can be read from the credentials source instead
+	// +optional
+	MetalHMAC string `json:"metalHMAC,omitempty"`
+	// MetalAuthType is the hmac auth type used for the metal-api, defaults to Metal-View
+	// +optional
+	MetalAuthType string `json:"metalAuthType,omitempty"`
+	// MetalAuth authenticates at the metal-api with credentials read from files, e.g. a service account token or a client certificate.
+	// A token replaces the hmac.
+	// +optional
+	MetalAuth *MetalAPIAuth `json:"metalAuth,omitempty"`
+	// MetalAPIs are further metal-apis, which are selected for the shoots by their region or their cloud profile.
+	// The shoots which do not match any of them use the metal-api configured above.
+	// +optional
+	MetalAPIs []MetalAPI `json:"metalAPIs,omitempty"`
+	// ProjectCacheTTL is the duration after which the projects fetched from the metal-api are refreshed, defaults to 30m
+	// +optional
+	ProjectCacheTTL *metav1.Duration `json:"projectCacheTTL,omitempty"`
+	// ProjectNotFoundCacheTTL is the duration for which a project that does not exist in the metal-api is not looked up again, defaults to 1m
+	// +optional
+	ProjectNotFoundCacheTTL *metav1.Duration `json:"projectNotFoundCacheTTL,omitempty"`
+	// ProjectCacheStaleWhileError serves expired projects from the cache when the metal-api cannot be reached, defaults to true
+	// +optional
+	ProjectCacheStaleWhileError *bool `json:"projectCacheStaleWhileError,omitempty"`
+
+	// AccountingHost the host domain to reach the accounting-api
+	AccountingHost string `json:"hostname"`
+	// AccountingPort the port to reach the accounting-api, defaults to 9000
+	// +optional
+	AccountingPort string `json:"port,omitempty"`
+	// CA is the ca certificate of the accounting-api, it can be read from the credentials source instead
+	// +optional
+	CA string `json:"ca,omitempty"`
+	// ClientCert is the client certificate to communicate with the accounting-api, it is shared by all shoots
+	// +optional
+	ClientCert string `json:"cert,omitempty"`
+	// ClientKey is the client key certificate to communicate with the accounting-api, it is shared by all shoots
+	// +optional
+	ClientKey string `json:"key,omitempty"`
+	// ClientCA issues a client certificate for every shoot to communicate with the accounting-api.
+	// If it is set, ClientCert and ClientKey are not used.
+	// +optional
+	ClientCA *ClientCA `json:"clientCA,omitempty"`
+	// Partitions maps partition ids to the accounting-api the accounting-exporters of the shoots in the partition report to.
+	// The shoots of all other partitions report to the accounting-api configured above.
+	// +optional
+	Partitions map[string]AccountingEndpoint `json:"partitions,omitempty"`
+
+	// ExporterPort is the port on which the accounting-exporter serves its health endpoint, defaults to 3000
+	// +optional
+	ExporterPort int32 `json:"exporterPort,omitempty"`
+	// ExporterResources are the resource requirements of the accounting-exporter, they can be overridden per shoot.
+	// Defaults to requests of 50m cpu and 64Mi memory and a limit of 512Mi memory.
+	// +optional
+	ExporterResources *corev1.ResourceRequirements `json:"exporterResources,omitempty"`
+	// ExporterMaxResources are the maximum requests and limits of the accounting-exporter per resource name, the resources
+	// overridden for a shoot are capped to them. Defaults to 1 cpu and 4Gi memory.
+	// +optional
+	ExporterMaxResources corev1.ResourceList `json:"exporterMaxResources,omitempty"`
+	// ExporterVPA configures the vertical pod autoscaler of the accounting-exporter
+	// +optional
+	ExporterVPA ExporterVPA `json:"exporterVPA,omitempty"`
+	// ExporterAvailability configures the scheduling and the disruptions of the accounting-exporter
+	// +optional
+	ExporterAvailability ExporterAvailability `json:"exporterAvailability,omitempty"`
+
+	// ProjectResolver configures where the tenant and the name of a shoot's project are looked up, defaults to the metal-api
+	// +optional
+	ProjectResolver ProjectResolver `json:"projectResolver,omitempty"`
+
+	// CredentialsSource references the credentials of the accounting instead of configuring them inline.
+	// The referenced credentials are reloaded when they change.
+	// +optional
+	CredentialsSource *CredentialsSource `json:"credentialsSource,omitempty"`
+}
 
+// MetalAPI is a metal-api, in which the projects of the shoots in its regions or with its cloud profiles are looked up.
+// The first metal-api selecting a shoot is used.
+type MetalAPI struct {
+	// Name identifies the metal-api in the status and the metrics
+	Name string `json:"name"`
+	// URL is the url of the metal-api
+	URL string `json:"url"`
+	// HMAC is the hmac used for the metal-api, it is not needed if a token is configured in auth
+	// +optional
+	HMAC string `json:"hmac,omitempty"`
+	// AuthType is the hmac auth type used for the metal-api, defaults to Metal-View
+	// +optional
+	AuthType string `json:"authType,omitempty"`
+	// Auth authenticates at the metal-api with credentials read from files, e.g. a service account token or a client certificate.
+	// A token replaces the hmac.
+	// +optional
+	Auth *MetalAPIAuth `json:"auth,omitempty"`
+	// Regions selects the metal-api for the shoots in these regions
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+	// CloudProfiles selects the metal-api for the shoots with these cloud profiles
+	// +optional
+	CloudProfiles []string `json:"cloudProfiles,omitempty"`
+}
+
+// MetalAPIAuth authenticates at a metal-api with credentials read from mounted files. The files are read whenever
+// a client for the metal-api is created, such that rotated credentials are picked up.
+type MetalAPIAuth struct {
+	// TokenFile is a file containing a bearer token, e.g. a projected service account token
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+	// ClientCertFile is a file containing a client certificate for mutual tls
+	// +optional
+	ClientCertFile string `json:"clientCertFile,omitempty"`
+	// ClientKeyFile is a file containing the private key of the client certificate
+	// +optional
+	ClientKeyFile string `json:"clientKeyFile,omitempty"`
+	// CAFile is a file containing the ca certificates to verify the metal-api, the system roots are used if empty
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// AccountingEndpoint is an accounting-api instance. The settings which are not configured are taken from the default accounting-api.
+type AccountingEndpoint struct {
 	// AccountingHost the host domain to reach the accounting-api
 	AccountingHost string `json:"hostname"`
 	// AccountingPort the port to reach the accounting-api
-	AccountingPort string `json:"port"`
+	// +optional
+	AccountingPort string `json:"port,omitempty"`
 	// CA is the ca certificate of the accounting-api
-	CA string `json:"ca"`
-	// ClientCert is the client certificate to communicate with the accounting-api
-	ClientCert string `json:"cert"`
-	// ClientKey is the client key certificate to communicate with the accounting-api
-	ClientKey string `json:"key"`
+	// +optional
+	CA string `json:"ca,omitempty"`
+	// ClientCert is the client certificate to communicate with the accounting-api, it is shared by all shoots of the partition.
+	// If it is not set, the client certificate of the default accounting-api is used.
+	// +optional
+	ClientCert string `json:"cert,omitempty"`
+	// ClientKey is the client key certificate to communicate with the accounting-api, it is shared by all shoots of the partition
+	// +optional
+	ClientKey string `json:"key,omitempty"`
+}
+
+// ExporterVPA configures the vertical pod autoscaler of the accounting-exporter.
+// The requests of the accounting-exporter are the lower bound of the recommendations.
+type ExporterVPA struct {
+	// Enabled deploys a vertical pod autoscaler for every accounting-exporter, defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxAllowed is the upper bound of the recommendations
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// ExporterAvailability configures the scheduling and the disruptions of the accounting-exporter in the seed.
+type ExporterAvailability struct {
+	// PriorityClassName is the priority class of the accounting-exporter, defaults to gardener-system-200
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// PodDisruptionBudget deploys a pod disruption budget for every accounting-exporter, defaults to true.
+	// Like the pod disruption budgets of the control plane components of gardener, it allows one unavailable pod and
+	// therefore does not prevent the eviction of the single accounting-exporter.
+	// +optional
+	PodDisruptionBudget *bool `json:"podDisruptionBudget,omitempty"`
+	// HighAvailability applies the high availability settings of gardener to the accounting-exporter if the control plane
+	// of the shoot is highly available, defaults to true.
+	// The accounting-exporter keeps running with a single replica as it would send the events multiple times otherwise.
+	// +optional
+	HighAvailability *bool `json:"highAvailability,omitempty"`
+}
+
+// CredentialsSource references the metal-api hmac, the accounting-api ca and the client certificates.
+// The credentials are read from the keys metalHMAC, ca, cert, key, clientCACert and clientCAKey.
+// Exactly one of SecretRef or Path must be set.
+type CredentialsSource struct {
+	// SecretRef references a secret containing the credentials.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	// Path is a directory containing the credentials as files, e.g. a mounted secret.
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+// ClientCA is the certificate authority issuing the client certificates of the accounting-exporters.
+type ClientCA struct {
+	// Certificate is the ca certificate, it can be read from the credentials source instead
+	// +optional
+	Certificate string `json:"cert,omitempty"`
+	// PrivateKey is the private key of the ca certificate, it can be read from the credentials source instead
+	// +optional
+	PrivateKey string `json:"key,omitempty"`
+	// Validity is the validity of the issued client certificates, they are renewed before they expire, defaults to 90d
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+}
+
+// ProjectResolverType is the type of a project resolver.
+type ProjectResolverType string
+
+const (
+	// ProjectResolverTypeMetal looks up the projects in the metal-api.
+	ProjectResolverTypeMetal ProjectResolverType = "metal"
+	// ProjectResolverTypeGarden reads the project metadata from annotations of the shoot and its project in the garden cluster.
+	ProjectResolverTypeGarden ProjectResolverType = "garden"
+	// ProjectResolverTypeStatic reads the project metadata from a static mapping in this configuration.
+	ProjectResolverTypeStatic ProjectResolverType = "static"
+)
+
+// ProjectResolver configures where the tenant and the name of a shoot's project are looked up.
+type ProjectResolver struct {
+	// Type is the type of the project resolver, one of metal, garden or static, defaults to metal
+	// +optional
+	Type ProjectResolverType `json:"type,omitempty"`
+	// ProviderTypes maps the provider types of the shoots to the project resolver of their shoots, the shoots of the other
+	// provider types use the project resolver of the type. The metal project resolver only resolves shoots of the provider type metal,
+	// the shoots of the other provider types fall back to the garden project resolver.
+	// +optional
+	ProviderTypes map[string]ProjectResolverType `json:"providerTypes,omitempty"`
+	// Static maps project ids to their metadata, only used by the static project resolver
+	// +optional
+	Static map[string]StaticProject `json:"static,omitempty"`
+	// Dimensions are the labels and annotations of the metal projects which are reported as additional accounting
+	// dimensions in the accounting status, only used by the metal project resolver
+	// +optional
+	Dimensions []ProjectDimension `json:"dimensions,omitempty"`
+}
+
+// ProjectDimension picks a label or an annotation of the metal project as an accounting dimension.
+type ProjectDimension struct {
+	// Key is the key of the label or the annotation of the project, annotations take precedence over labels
+	Key string `json:"key"`
+	// Name is the name of the accounting dimension
+	Name string `json:"name"`
+}
+
+// StaticProject contains the metadata of a project for the static project resolver.
+type StaticProject struct {
+	// Name is the name of the project
+	Name string `json:"name"`
+	// TenantID is the id of the tenant the project belongs to
+	TenantID string `json:"tenantID"`
 }
 
 // ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
@@ -14,6 +14,8 @@ import (
 
 	config "github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
 	configv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -35,6 +37,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AccountingEndpoint)(nil), (*config.AccountingEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AccountingEndpoint_To_config_AccountingEndpoint(a.(*AccountingEndpoint), b.(*config.AccountingEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AccountingEndpoint)(nil), (*AccountingEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AccountingEndpoint_To_v1alpha1_AccountingEndpoint(a.(*config.AccountingEndpoint), b.(*AccountingEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClientCA)(nil), (*config.ClientCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClientCA_To_config_ClientCA(a.(*ClientCA), b.(*config.ClientCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ClientCA)(nil), (*ClientCA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ClientCA_To_v1alpha1_ClientCA(a.(*config.ClientCA), b.(*ClientCA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterwideNetworkPolicy)(nil), (*config.ClusterwideNetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy(a.(*ClusterwideNetworkPolicy), b.(*config.ClusterwideNetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ClusterwideNetworkPolicy)(nil), (*ClusterwideNetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy(a.(*config.ClusterwideNetworkPolicy), b.(*ClusterwideNetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -45,6 +77,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CredentialsSource)(nil), (*config.CredentialsSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CredentialsSource_To_config_CredentialsSource(a.(*CredentialsSource), b.(*config.CredentialsSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CredentialsSource)(nil), (*CredentialsSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CredentialsSource_To_v1alpha1_CredentialsSource(a.(*config.CredentialsSource), b.(*CredentialsSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExporterAvailability)(nil), (*config.ExporterAvailability)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability(a.(*ExporterAvailability), b.(*config.ExporterAvailability), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ExporterAvailability)(nil), (*ExporterAvailability)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability(a.(*config.ExporterAvailability), b.(*ExporterAvailability), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExporterVPA)(nil), (*config.ExporterVPA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExporterVPA_To_config_ExporterVPA(a.(*ExporterVPA), b.(*config.ExporterVPA), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ExporterVPA)(nil), (*ExporterVPA)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ExporterVPA_To_v1alpha1_ExporterVPA(a.(*config.ExporterVPA), b.(*ExporterVPA), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ImagePullSecret)(nil), (*config.ImagePullSecret)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(a.(*ImagePullSecret), b.(*config.ImagePullSecret), scope)
 	}); err != nil {
@@ -55,6 +117,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MetalAPI)(nil), (*config.MetalAPI)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetalAPI_To_config_MetalAPI(a.(*MetalAPI), b.(*config.MetalAPI), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MetalAPI)(nil), (*MetalAPI)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MetalAPI_To_v1alpha1_MetalAPI(a.(*config.MetalAPI), b.(*MetalAPI), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MetalAPIAuth)(nil), (*config.MetalAPIAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetalAPIAuth_To_config_MetalAPIAuth(a.(*MetalAPIAuth), b.(*config.MetalAPIAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MetalAPIAuth)(nil), (*MetalAPIAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MetalAPIAuth_To_v1alpha1_MetalAPIAuth(a.(*config.MetalAPIAuth), b.(*MetalAPIAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Monitoring)(nil), (*config.Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Monitoring_To_config_Monitoring(a.(*Monitoring), b.(*config.Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Monitoring)(nil), (*Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Monitoring_To_v1alpha1_Monitoring(a.(*config.Monitoring), b.(*Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicy)(nil), (*config.NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(a.(*NetworkPolicy), b.(*config.NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NetworkPolicy)(nil), (*NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(a.(*config.NetworkPolicy), b.(*NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProjectDimension)(nil), (*config.ProjectDimension)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProjectDimension_To_config_ProjectDimension(a.(*ProjectDimension), b.(*config.ProjectDimension), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ProjectDimension)(nil), (*ProjectDimension)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ProjectDimension_To_v1alpha1_ProjectDimension(a.(*config.ProjectDimension), b.(*ProjectDimension), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProjectResolver)(nil), (*config.ProjectResolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProjectResolver_To_config_ProjectResolver(a.(*ProjectResolver), b.(*config.ProjectResolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ProjectResolver)(nil), (*ProjectResolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ProjectResolver_To_v1alpha1_ProjectResolver(a.(*config.ProjectResolver), b.(*ProjectResolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticProject)(nil), (*config.StaticProject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StaticProject_To_config_StaticProject(a.(*StaticProject), b.(*config.StaticProject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.StaticProject)(nil), (*StaticProject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_StaticProject_To_v1alpha1_StaticProject(a.(*config.StaticProject), b.(*StaticProject), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -62,11 +194,31 @@ func autoConvert_v1alpha1_Accounting_To_config_Accounting(in *Accounting, out *c
 	out.MetalURL = in.MetalURL
 	out.MetalHMAC = in.MetalHMAC
 	out.MetalAuthType = in.MetalAuthType
+	out.MetalAuth = (*config.MetalAPIAuth)(unsafe.Pointer(in.MetalAuth))
+	out.MetalAPIs = *(*[]config.MetalAPI)(unsafe.Pointer(&in.MetalAPIs))
+	out.ProjectCacheTTL = (*v1.Duration)(unsafe.Pointer(in.ProjectCacheTTL))
+	out.ProjectNotFoundCacheTTL = (*v1.Duration)(unsafe.Pointer(in.ProjectNotFoundCacheTTL))
+	out.ProjectCacheStaleWhileError = (*bool)(unsafe.Pointer(in.ProjectCacheStaleWhileError))
 	out.AccountingHost = in.AccountingHost
 	out.AccountingPort = in.AccountingPort
 	out.CA = in.CA
 	out.ClientCert = in.ClientCert
 	out.ClientKey = in.ClientKey
+	out.ClientCA = (*config.ClientCA)(unsafe.Pointer(in.ClientCA))
+	out.Partitions = *(*map[string]config.AccountingEndpoint)(unsafe.Pointer(&in.Partitions))
+	out.ExporterPort = in.ExporterPort
+	out.ExporterResources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.ExporterResources))
+	out.ExporterMaxResources = *(*corev1.ResourceList)(unsafe.Pointer(&in.ExporterMaxResources))
+	if err := Convert_v1alpha1_ExporterVPA_To_config_ExporterVPA(&in.ExporterVPA, &out.ExporterVPA, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability(&in.ExporterAvailability, &out.ExporterAvailability, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ProjectResolver_To_config_ProjectResolver(&in.ProjectResolver, &out.ProjectResolver, s); err != nil {
+		return err
+	}
+	out.CredentialsSource = (*config.CredentialsSource)(unsafe.Pointer(in.CredentialsSource))
 	return nil
 }
 
@@ -79,11 +231,31 @@ func autoConvert_config_Accounting_To_v1alpha1_Accounting(in *config.Accounting,
 	out.MetalURL = in.MetalURL
 	out.MetalHMAC = in.MetalHMAC
 	out.MetalAuthType = in.MetalAuthType
+	out.MetalAuth = (*MetalAPIAuth)(unsafe.Pointer(in.MetalAuth))
+	out.MetalAPIs = *(*[]MetalAPI)(unsafe.Pointer(&in.MetalAPIs))
+	out.ProjectCacheTTL = (*v1.Duration)(unsafe.Pointer(in.ProjectCacheTTL))
+	out.ProjectNotFoundCacheTTL = (*v1.Duration)(unsafe.Pointer(in.ProjectNotFoundCacheTTL))
+	out.ProjectCacheStaleWhileError = (*bool)(unsafe.Pointer(in.ProjectCacheStaleWhileError))
 	out.AccountingHost = in.AccountingHost
 	out.AccountingPort = in.AccountingPort
 	out.CA = in.CA
 	out.ClientCert = in.ClientCert
 	out.ClientKey = in.ClientKey
+	out.ClientCA = (*ClientCA)(unsafe.Pointer(in.ClientCA))
+	out.Partitions = *(*map[string]AccountingEndpoint)(unsafe.Pointer(&in.Partitions))
+	out.ExporterPort = in.ExporterPort
+	out.ExporterResources = (*corev1.ResourceRequirements)(unsafe.Pointer(in.ExporterResources))
+	out.ExporterMaxResources = *(*corev1.ResourceList)(unsafe.Pointer(&in.ExporterMaxResources))
+	if err := Convert_config_ExporterVPA_To_v1alpha1_ExporterVPA(&in.ExporterVPA, &out.ExporterVPA, s); err != nil {
+		return err
+	}
+	if err := Convert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability(&in.ExporterAvailability, &out.ExporterAvailability, s); err != nil {
+		return err
+	}
+	if err := Convert_config_ProjectResolver_To_v1alpha1_ProjectResolver(&in.ProjectResolver, &out.ProjectResolver, s); err != nil {
+		return err
+	}
+	out.CredentialsSource = (*CredentialsSource)(unsafe.Pointer(in.CredentialsSource))
 	return nil
 }
 
@@ -92,12 +264,97 @@ func Convert_config_Accounting_To_v1alpha1_Accounting(in *config.Accounting, out
 	return autoConvert_config_Accounting_To_v1alpha1_Accounting(in, out, s)
 }
 
+func autoConvert_v1alpha1_AccountingEndpoint_To_config_AccountingEndpoint(in *AccountingEndpoint, out *config.AccountingEndpoint, s conversion.Scope) error {
+	out.AccountingHost = in.AccountingHost
+	out.AccountingPort = in.AccountingPort
+	out.CA = in.CA
+	out.ClientCert = in.ClientCert
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_v1alpha1_AccountingEndpoint_To_config_AccountingEndpoint is an autogenerated conversion function.
+func Convert_v1alpha1_AccountingEndpoint_To_config_AccountingEndpoint(in *AccountingEndpoint, out *config.AccountingEndpoint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AccountingEndpoint_To_config_AccountingEndpoint(in, out, s)
+}
+
+func autoConvert_config_AccountingEndpoint_To_v1alpha1_AccountingEndpoint(in *config.AccountingEndpoint, out *AccountingEndpoint, s conversion.Scope) error {
+	out.AccountingHost = in.AccountingHost
+	out.AccountingPort = in.AccountingPort
+	out.CA = in.CA
+	out.ClientCert = in.ClientCert
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_config_AccountingEndpoint_To_v1alpha1_AccountingEndpoint is an autogenerated conversion function.
+func Convert_config_AccountingEndpoint_To_v1alpha1_AccountingEndpoint(in *config.AccountingEndpoint, out *AccountingEndpoint, s conversion.Scope) error {
+	return autoConvert_config_AccountingEndpoint_To_v1alpha1_AccountingEndpoint(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClientCA_To_config_ClientCA(in *ClientCA, out *config.ClientCA, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.Validity = (*v1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_v1alpha1_ClientCA_To_config_ClientCA is an autogenerated conversion function.
+func Convert_v1alpha1_ClientCA_To_config_ClientCA(in *ClientCA, out *config.ClientCA, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClientCA_To_config_ClientCA(in, out, s)
+}
+
+func autoConvert_config_ClientCA_To_v1alpha1_ClientCA(in *config.ClientCA, out *ClientCA, s conversion.Scope) error {
+	out.Certificate = in.Certificate
+	out.PrivateKey = in.PrivateKey
+	out.Validity = (*v1.Duration)(unsafe.Pointer(in.Validity))
+	return nil
+}
+
+// Convert_config_ClientCA_To_v1alpha1_ClientCA is an autogenerated conversion function.
+func Convert_config_ClientCA_To_v1alpha1_ClientCA(in *config.ClientCA, out *ClientCA, s conversion.Scope) error {
+	return autoConvert_config_ClientCA_To_v1alpha1_ClientCA(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy(in *ClusterwideNetworkPolicy, out *config.ClusterwideNetworkPolicy, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy(in *ClusterwideNetworkPolicy, out *config.ClusterwideNetworkPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy(in, out, s)
+}
+
+func autoConvert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy(in *config.ClusterwideNetworkPolicy, out *ClusterwideNetworkPolicy, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy is an autogenerated conversion function.
+func Convert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy(in *config.ClusterwideNetworkPolicy, out *ClusterwideNetworkPolicy, s conversion.Scope) error {
+	return autoConvert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	if err := Convert_v1alpha1_Accounting_To_config_Accounting(&in.Accounting, &out.Accounting, s); err != nil {
 		return err
 	}
 	out.HealthCheckConfig = (*configv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.ImagePullSecret = (*config.ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
+	if err := Convert_v1alpha1_ClusterwideNetworkPolicy_To_config_ClusterwideNetworkPolicy(&in.ClusterwideNetworkPolicy, &out.ClusterwideNetworkPolicy, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(&in.NetworkPolicy, &out.NetworkPolicy, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_Monitoring_To_config_Monitoring(&in.Monitoring, &out.Monitoring, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -112,6 +369,15 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	}
 	out.HealthCheckConfig = (*configv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.ImagePullSecret = (*ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
+	if err := Convert_config_ClusterwideNetworkPolicy_To_v1alpha1_ClusterwideNetworkPolicy(&in.ClusterwideNetworkPolicy, &out.ClusterwideNetworkPolicy, s); err != nil {
+		return err
+	}
+	if err := Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(&in.NetworkPolicy, &out.NetworkPolicy, s); err != nil {
+		return err
+	}
+	if err := Convert_config_Monitoring_To_v1alpha1_Monitoring(&in.Monitoring, &out.Monitoring, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -120,6 +386,74 @@ func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(
 	return autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_CredentialsSource_To_config_CredentialsSource(in *CredentialsSource, out *config.CredentialsSource, s conversion.Scope) error {
+	out.SecretRef = (*corev1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Path = (*string)(unsafe.Pointer(in.Path))
+	return nil
+}
+
+// Convert_v1alpha1_CredentialsSource_To_config_CredentialsSource is an autogenerated conversion function.
+func Convert_v1alpha1_CredentialsSource_To_config_CredentialsSource(in *CredentialsSource, out *config.CredentialsSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CredentialsSource_To_config_CredentialsSource(in, out, s)
+}
+
+func autoConvert_config_CredentialsSource_To_v1alpha1_CredentialsSource(in *config.CredentialsSource, out *CredentialsSource, s conversion.Scope) error {
+	out.SecretRef = (*corev1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Path = (*string)(unsafe.Pointer(in.Path))
+	return nil
+}
+
+// Convert_config_CredentialsSource_To_v1alpha1_CredentialsSource is an autogenerated conversion function.
+func Convert_config_CredentialsSource_To_v1alpha1_CredentialsSource(in *config.CredentialsSource, out *CredentialsSource, s conversion.Scope) error {
+	return autoConvert_config_CredentialsSource_To_v1alpha1_CredentialsSource(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability(in *ExporterAvailability, out *config.ExporterAvailability, s conversion.Scope) error {
+	out.PriorityClassName = in.PriorityClassName
+	out.PodDisruptionBudget = (*bool)(unsafe.Pointer(in.PodDisruptionBudget))
+	out.HighAvailability = (*bool)(unsafe.Pointer(in.HighAvailability))
+	return nil
+}
+
+// Convert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability is an autogenerated conversion function.
+func Convert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability(in *ExporterAvailability, out *config.ExporterAvailability, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExporterAvailability_To_config_ExporterAvailability(in, out, s)
+}
+
+func autoConvert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability(in *config.ExporterAvailability, out *ExporterAvailability, s conversion.Scope) error {
+	out.PriorityClassName = in.PriorityClassName
+	out.PodDisruptionBudget = (*bool)(unsafe.Pointer(in.PodDisruptionBudget))
+	out.HighAvailability = (*bool)(unsafe.Pointer(in.HighAvailability))
+	return nil
+}
+
+// Convert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability is an autogenerated conversion function.
+func Convert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability(in *config.ExporterAvailability, out *ExporterAvailability, s conversion.Scope) error {
+	return autoConvert_config_ExporterAvailability_To_v1alpha1_ExporterAvailability(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExporterVPA_To_config_ExporterVPA(in *ExporterVPA, out *config.ExporterVPA, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.MaxAllowed = *(*corev1.ResourceList)(unsafe.Pointer(&in.MaxAllowed))
+	return nil
+}
+
+// Convert_v1alpha1_ExporterVPA_To_config_ExporterVPA is an autogenerated conversion function.
+func Convert_v1alpha1_ExporterVPA_To_config_ExporterVPA(in *ExporterVPA, out *config.ExporterVPA, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExporterVPA_To_config_ExporterVPA(in, out, s)
+}
+
+func autoConvert_config_ExporterVPA_To_v1alpha1_ExporterVPA(in *config.ExporterVPA, out *ExporterVPA, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.MaxAllowed = *(*corev1.ResourceList)(unsafe.Pointer(&in.MaxAllowed))
+	return nil
+}
+
+// Convert_config_ExporterVPA_To_v1alpha1_ExporterVPA is an autogenerated conversion function.
+func Convert_config_ExporterVPA_To_v1alpha1_ExporterVPA(in *config.ExporterVPA, out *ExporterVPA, s conversion.Scope) error {
+	return autoConvert_config_ExporterVPA_To_v1alpha1_ExporterVPA(in, out, s)
+}
+
 func autoConvert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(in *ImagePullSecret, out *config.ImagePullSecret, s conversion.Scope) error {
 	out.DockerConfigJSON = in.DockerConfigJSON
 	return nil
@@ -139,3 +473,179 @@ func autoConvert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in *config.I
 func Convert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in *config.ImagePullSecret, out *ImagePullSecret, s conversion.Scope) error {
 	return autoConvert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in, out, s)
 }
+
+func autoConvert_v1alpha1_MetalAPI_To_config_MetalAPI(in *MetalAPI, out *config.MetalAPI, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.HMAC = in.HMAC
+	out.AuthType = in.AuthType
+	out.Auth = (*config.MetalAPIAuth)(unsafe.Pointer(in.Auth))
+	out.Regions = *(*[]string)(unsafe.Pointer(&in.Regions))
+	out.CloudProfiles = *(*[]string)(unsafe.Pointer(&in.CloudProfiles))
+	return nil
+}
+
+// Convert_v1alpha1_MetalAPI_To_config_MetalAPI is an autogenerated conversion function.
+func Convert_v1alpha1_MetalAPI_To_config_MetalAPI(in *MetalAPI, out *config.MetalAPI, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetalAPI_To_config_MetalAPI(in, out, s)
+}
+
+func autoConvert_config_MetalAPI_To_v1alpha1_MetalAPI(in *config.MetalAPI, out *MetalAPI, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.HMAC = in.HMAC
+	out.AuthType = in.AuthType
+	out.Auth = (*MetalAPIAuth)(unsafe.Pointer(in.Auth))
+	out.Regions = *(*[]string)(unsafe.Pointer(&in.Regions))
+	out.CloudProfiles = *(*[]string)(unsafe.Pointer(&in.CloudProfiles))
+	return nil
+}
+
+// Convert_config_MetalAPI_To_v1alpha1_MetalAPI is an autogenerated conversion function.
+func Convert_config_MetalAPI_To_v1alpha1_MetalAPI(in *config.MetalAPI, out *MetalAPI, s conversion.Scope) error {
+	return autoConvert_config_MetalAPI_To_v1alpha1_MetalAPI(in, out, s)
+}
+
+func autoConvert_v1alpha1_MetalAPIAuth_To_config_MetalAPIAuth(in *MetalAPIAuth, out *config.MetalAPIAuth, s conversion.Scope) error {
+	out.TokenFile = in.TokenFile
+	out.ClientCertFile = in.ClientCertFile
+	out.ClientKeyFile = in.ClientKeyFile
+	out.CAFile = in.CAFile
+	return nil
+}
+
+// Convert_v1alpha1_MetalAPIAuth_To_config_MetalAPIAuth is an autogenerated conversion function.
+func Convert_v1alpha1_MetalAPIAuth_To_config_MetalAPIAuth(in *MetalAPIAuth, out *config.MetalAPIAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetalAPIAuth_To_config_MetalAPIAuth(in, out, s)
+}
+
+func autoConvert_config_MetalAPIAuth_To_v1alpha1_MetalAPIAuth(in *config.MetalAPIAuth, out *MetalAPIAuth, s conversion.Scope) error {
+	out.TokenFile = in.TokenFile
+	out.ClientCertFile = in.ClientCertFile
+	out.ClientKeyFile = in.ClientKeyFile
+	out.CAFile = in.CAFile
+	return nil
+}
+
+// Convert_config_MetalAPIAuth_To_v1alpha1_MetalAPIAuth is an autogenerated conversion function.
+func Convert_config_MetalAPIAuth_To_v1alpha1_MetalAPIAuth(in *config.MetalAPIAuth, out *MetalAPIAuth, s conversion.Scope) error {
+	return autoConvert_config_MetalAPIAuth_To_v1alpha1_MetalAPIAuth(in, out, s)
+}
+
+func autoConvert_v1alpha1_Monitoring_To_config_Monitoring(in *Monitoring, out *config.Monitoring, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.LastEventSentMetric = in.LastEventSentMetric
+	out.NoEventsSentAlertThreshold = (*v1.Duration)(unsafe.Pointer(in.NoEventsSentAlertThreshold))
+	return nil
+}
+
+// Convert_v1alpha1_Monitoring_To_config_Monitoring is an autogenerated conversion function.
+func Convert_v1alpha1_Monitoring_To_config_Monitoring(in *Monitoring, out *config.Monitoring, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Monitoring_To_config_Monitoring(in, out, s)
+}
+
+func autoConvert_config_Monitoring_To_v1alpha1_Monitoring(in *config.Monitoring, out *Monitoring, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.LastEventSentMetric = in.LastEventSentMetric
+	out.NoEventsSentAlertThreshold = (*v1.Duration)(unsafe.Pointer(in.NoEventsSentAlertThreshold))
+	return nil
+}
+
+// Convert_config_Monitoring_To_v1alpha1_Monitoring is an autogenerated conversion function.
+func Convert_config_Monitoring_To_v1alpha1_Monitoring(in *config.Monitoring, out *Monitoring, s conversion.Scope) error {
+	return autoConvert_config_Monitoring_To_v1alpha1_Monitoring(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in *NetworkPolicy, out *config.NetworkPolicy, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in *NetworkPolicy, out *config.NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *config.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy is an autogenerated conversion function.
+func Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *config.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProjectDimension_To_config_ProjectDimension(in *ProjectDimension, out *config.ProjectDimension, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_ProjectDimension_To_config_ProjectDimension is an autogenerated conversion function.
+func Convert_v1alpha1_ProjectDimension_To_config_ProjectDimension(in *ProjectDimension, out *config.ProjectDimension, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProjectDimension_To_config_ProjectDimension(in, out, s)
+}
+
+func autoConvert_config_ProjectDimension_To_v1alpha1_ProjectDimension(in *config.ProjectDimension, out *ProjectDimension, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_config_ProjectDimension_To_v1alpha1_ProjectDimension is an autogenerated conversion function.
+func Convert_config_ProjectDimension_To_v1alpha1_ProjectDimension(in *config.ProjectDimension, out *ProjectDimension, s conversion.Scope) error {
+	return autoConvert_config_ProjectDimension_To_v1alpha1_ProjectDimension(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProjectResolver_To_config_ProjectResolver(in *ProjectResolver, out *config.ProjectResolver, s conversion.Scope) error {
+	out.Type = config.ProjectResolverType(in.Type)
+	out.ProviderTypes = *(*map[string]config.ProjectResolverType)(unsafe.Pointer(&in.ProviderTypes))
+	out.Static = *(*map[string]config.StaticProject)(unsafe.Pointer(&in.Static))
+	out.Dimensions = *(*[]config.ProjectDimension)(unsafe.Pointer(&in.Dimensions))
+	return nil
+}
+
+// Convert_v1alpha1_ProjectResolver_To_config_ProjectResolver is an autogenerated conversion function.
+func Convert_v1alpha1_ProjectResolver_To_config_ProjectResolver(in *ProjectResolver, out *config.ProjectResolver, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProjectResolver_To_config_ProjectResolver(in, out, s)
+}
+
+func autoConvert_config_ProjectResolver_To_v1alpha1_ProjectResolver(in *config.ProjectResolver, out *ProjectResolver, s conversion.Scope) error {
+	out.Type = ProjectResolverType(in.Type)
+	out.ProviderTypes = *(*map[string]ProjectResolverType)(unsafe.Pointer(&in.ProviderTypes))
+	out.Static = *(*map[string]StaticProject)(unsafe.Pointer(&in.Static))
+	out.Dimensions = *(*[]ProjectDimension)(unsafe.Pointer(&in.Dimensions))
+	return nil
+}
+
+// Convert_config_ProjectResolver_To_v1alpha1_ProjectResolver is an autogenerated conversion function.
+func Convert_config_ProjectResolver_To_v1alpha1_ProjectResolver(in *config.ProjectResolver, out *ProjectResolver, s conversion.Scope) error {
+	return autoConvert_config_ProjectResolver_To_v1alpha1_ProjectResolver(in, out, s)
+}
+
+func autoConvert_v1alpha1_StaticProject_To_config_StaticProject(in *StaticProject, out *config.StaticProject, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TenantID = in.TenantID
+	return nil
+}
+
+// Convert_v1alpha1_StaticProject_To_config_StaticProject is an autogenerated conversion function.
+func Convert_v1alpha1_StaticProject_To_config_StaticProject(in *StaticProject, out *config.StaticProject, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StaticProject_To_config_StaticProject(in, out, s)
+}
+
+func autoConvert_config_StaticProject_To_v1alpha1_StaticProject(in *config.StaticProject, out *StaticProject, s conversion.Scope) error {
+	out.Name = in.Name
+	out.TenantID = in.TenantID
+	return nil
+}
+
+// Convert_config_StaticProject_To_v1alpha1_StaticProject is an autogenerated conversion function.
+func Convert_config_StaticProject_To_v1alpha1_StaticProject(in *config.StaticProject, out *StaticProject, s conversion.Scope) error {
+	return autoConvert_config_StaticProject_To_v1alpha1_StaticProject(in, out, s)
+}
@@ -17,5 +17,23 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ControllerConfiguration{}, func(obj interface{}) { SetObjectDefaults_ControllerConfiguration(obj.(*ControllerConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_ControllerConfiguration(in *ControllerConfiguration) {
+	SetDefaults_Accounting(&in.Accounting)
+	for i := range in.Accounting.MetalAPIs {
+		a := &in.Accounting.MetalAPIs[i]
+		SetDefaults_MetalAPI(a)
+	}
+	if in.Accounting.ClientCA != nil {
+		SetDefaults_ClientCA(in.Accounting.ClientCA)
+	}
+	SetDefaults_ExporterVPA(&in.Accounting.ExporterVPA)
+	SetDefaults_ExporterAvailability(&in.Accounting.ExporterAvailability)
+	SetDefaults_ProjectResolver(&in.Accounting.ProjectResolver)
+	SetDefaults_ClusterwideNetworkPolicy(&in.ClusterwideNetworkPolicy)
+	SetDefaults_NetworkPolicy(&in.NetworkPolicy)
+	SetDefaults_Monitoring(&in.Monitoring)
+}
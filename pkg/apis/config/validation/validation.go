@@ -0,0 +1,543 @@
+package validation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	accountingvalidation "github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/validation"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/identity"
+)
+
+var (
+	supportedMetalAuthTypes       = sets.New("Metal-View", "Metal-Edit", "Metal-Admin")
+	supportedProjectResolverTypes = sets.New(config.ProjectResolverTypeMetal, config.ProjectResolverTypeGarden, config.ProjectResolverTypeStatic)
+	metricNameRegex               = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	dimensionNameRegex            = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// minClientCertificateValidity ensures that the client certificates are not renewed on every reconciliation,
+// the secrets manager renews certificates at the latest ten days before they expire.
+const minClientCertificateValidity = 30 * 24 * time.Hour
+
+// ValidateConfiguration validates the passed configuration instance.
+func ValidateConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateAccounting(&cfg.Accounting, field.NewPath("accounting"))...)
+	allErrs = append(allErrs, validateClusterwideNetworkPolicy(&cfg.ClusterwideNetworkPolicy, field.NewPath("clusterwideNetworkPolicy"))...)
+	allErrs = append(allErrs, validateNetworkPolicy(&cfg.NetworkPolicy, field.NewPath("networkPolicy"))...)
+	allErrs = append(allErrs, validateMonitoring(&cfg.Monitoring, field.NewPath("monitoring"))...)
+
+	if cfg.ImagePullSecret != nil && cfg.ImagePullSecret.DockerConfigJSON != "" {
+		if _, err := base64.StdEncoding.DecodeString(cfg.ImagePullSecret.DockerConfigJSON); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("imagePullSecret", "encodedDockerConfigJSON"), "<redacted>", fmt.Sprintf("must be base64 encoded: %s", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAccounting(accounting *config.Accounting, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateProjectResolver(accounting, fldPath)...)
+	allErrs = append(allErrs, validateHost(accounting.AccountingHost, fldPath.Child("hostname"))...)
+	allErrs = append(allErrs, validatePort(accounting.AccountingPort, fldPath.Child("port"))...)
+
+	if accounting.CredentialsSource != nil {
+		allErrs = append(allErrs, validateCredentialsSource(accounting.CredentialsSource, fldPath.Child("credentialsSource"))...)
+	} else {
+		allErrs = append(allErrs, ValidateCredentials(accounting, fldPath)...)
+	}
+
+	if accounting.ClientCA != nil {
+		validityPath := fldPath.Child("clientCA", "validity")
+		if accounting.ClientCA.Validity == nil {
+			allErrs = append(allErrs, field.Required(validityPath, "client certificate validity must be set"))
+		} else if accounting.ClientCA.Validity.Duration < minClientCertificateValidity {
+			allErrs = append(allErrs, field.Invalid(validityPath, accounting.ClientCA.Validity.Duration.String(), fmt.Sprintf("client certificate validity must be at least %s", minClientCertificateValidity)))
+		}
+	}
+
+	for partition, endpoint := range accounting.Partitions {
+		if partition == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("partitions"), "partition id must not be empty"))
+		}
+		allErrs = append(allErrs, validateAccountingEndpoint(&endpoint, fldPath.Child("partitions").Key(partition))...)
+	}
+
+	for _, msg := range validation.IsValidPortNum(int(accounting.ExporterPort)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("exporterPort"), accounting.ExporterPort, msg))
+	}
+
+	allErrs = append(allErrs, accountingvalidation.ValidateMaxResources(accounting.ExporterMaxResources, fldPath.Child("exporterMaxResources"))...)
+
+	if accounting.ExporterResources != nil {
+		allErrs = append(allErrs, accountingvalidation.ValidateResourceRequirements(accounting.ExporterResources, fldPath.Child("exporterResources"))...)
+		allErrs = append(allErrs, accountingvalidation.ValidateResourceRequirementsWithin(accounting.ExporterResources, accounting.ExporterMaxResources, fldPath.Child("exporterResources"))...)
+	}
+
+	priorityClassPath := fldPath.Child("exporterAvailability", "priorityClassName")
+	if accounting.ExporterAvailability.PriorityClassName == "" {
+		allErrs = append(allErrs, field.Required(priorityClassPath, "priority class name must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(accounting.ExporterAvailability.PriorityClassName) {
+			allErrs = append(allErrs, field.Invalid(priorityClassPath, accounting.ExporterAvailability.PriorityClassName, msg))
+		}
+	}
+
+	for name, quantity := range accounting.ExporterVPA.MaxAllowed {
+		if quantity.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("exporterVPA", "maxAllowed").Key(string(name)), quantity.String(), "must be positive"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAccountingEndpoint(endpoint *config.AccountingEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateHost(endpoint.AccountingHost, fldPath.Child("hostname"))...)
+
+	if endpoint.AccountingPort != "" {
+		allErrs = append(allErrs, validatePort(endpoint.AccountingPort, fldPath.Child("port"))...)
+	}
+
+	return allErrs
+}
+
+// validateAccountingEndpointCredentials validates the credentials of a partition, the ones which are not set are taken from the default accounting-api.
+func validateAccountingEndpointCredentials(endpoint *config.AccountingEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if endpoint.CA != "" {
+		if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(endpoint.CA)); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), "<redacted>", "unable to parse ca certificate"))
+		}
+	}
+
+	if endpoint.ClientCert != "" || endpoint.ClientKey != "" {
+		allErrs = append(allErrs, validateKeyPair(endpoint.ClientCert, endpoint.ClientKey, fldPath)...)
+	}
+
+	return allErrs
+}
+
+func validateProjectResolver(accounting *config.Accounting, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	resolverPath := fldPath.Child("projectResolver")
+
+	if !supportedProjectResolverTypes.Has(accounting.ProjectResolver.Type) {
+		allErrs = append(allErrs, field.NotSupported(resolverPath.Child("type"), accounting.ProjectResolver.Type, sets.List(supportedProjectResolverTypes)))
+	}
+
+	// the provider types are sorted, so that the errors have a stable order
+	for _, providerType := range sets.List(sets.KeySet(accounting.ProjectResolver.ProviderTypes)) {
+		resolverType := accounting.ProjectResolver.ProviderTypes[providerType]
+		providerTypePath := resolverPath.Child("providerTypes").Key(providerType)
+
+		if !supportedProjectResolverTypes.Has(resolverType) {
+			allErrs = append(allErrs, field.NotSupported(providerTypePath, resolverType, sets.List(supportedProjectResolverTypes)))
+		} else if resolverType == config.ProjectResolverTypeMetal && providerType != identity.ProviderTypeMetal {
+			allErrs = append(allErrs, field.Invalid(providerTypePath, resolverType, "metal project resolver only resolves shoots of the provider type metal"))
+		}
+	}
+
+	if usesProjectResolver(accounting, config.ProjectResolverTypeMetal) {
+		// the default metal-api is optional if the shoots are selected by further metal-apis
+		if accounting.MetalURL != "" || len(accounting.MetalAPIs) == 0 {
+			allErrs = append(allErrs, validateURL(accounting.MetalURL, fldPath.Child("metalURL"))...)
+
+			if !supportedMetalAuthTypes.Has(accounting.MetalAuthType) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("metalAuthType"), accounting.MetalAuthType, sets.List(supportedMetalAuthTypes)))
+			}
+		}
+
+		if accounting.MetalAuth != nil {
+			allErrs = append(allErrs, validateMetalAPIAuth(accounting.MetalAuth, fldPath.Child("metalAuth"))...)
+		}
+
+		allErrs = append(allErrs, validateMetalAPIs(accounting.MetalAPIs, fldPath.Child("metalAPIs"))...)
+
+		if accounting.ProjectCacheTTL == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("projectCacheTTL"), "project cache ttl must be set"))
+		} else if accounting.ProjectCacheTTL.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("projectCacheTTL"), accounting.ProjectCacheTTL.Duration.String(), "project cache ttl must be positive"))
+		}
+
+		if accounting.ProjectNotFoundCacheTTL != nil && accounting.ProjectNotFoundCacheTTL.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("projectNotFoundCacheTTL"), accounting.ProjectNotFoundCacheTTL.Duration.String(), "project not found cache ttl must not be negative"))
+		}
+
+		allErrs = append(allErrs, validateProjectDimensions(accounting.ProjectResolver.Dimensions, resolverPath.Child("dimensions"))...)
+	}
+
+	if usesProjectResolver(accounting, config.ProjectResolverTypeStatic) {
+		if len(accounting.ProjectResolver.Static) == 0 {
+			allErrs = append(allErrs, field.Required(resolverPath.Child("static"), "static project mapping must not be empty"))
+		}
+
+		for id, project := range accounting.ProjectResolver.Static {
+			if project.TenantID == "" {
+				allErrs = append(allErrs, field.Required(resolverPath.Child("static").Key(id).Child("tenantID"), "tenant id must be set"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// usesProjectResolver returns whether the project resolver of the given type resolves the shoots of any provider type.
+func usesProjectResolver(accounting *config.Accounting, resolverType config.ProjectResolverType) bool {
+	if accounting.ProjectResolver.Type == resolverType {
+		return true
+	}
+
+	for _, t := range accounting.ProjectResolver.ProviderTypes {
+		if t == resolverType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateProjectDimensions(dimensions []config.ProjectDimension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.New[string]()
+	for i, dimension := range dimensions {
+		idxPath := fldPath.Index(i)
+
+		if dimension.Key == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("key"), "key must be set"))
+		}
+
+		switch {
+		case !dimensionNameRegex.MatchString(dimension.Name):
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), dimension.Name, fmt.Sprintf("must match %s", dimensionNameRegex.String())))
+		case names.Has(dimension.Name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), dimension.Name))
+		}
+		names.Insert(dimension.Name)
+	}
+
+	return allErrs
+}
+
+func validateMetalAPIs(apis []config.MetalAPI, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.New[string]()
+	for i, api := range apis {
+		idxPath := fldPath.Index(i)
+
+		switch {
+		case api.Name == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "metal-api name must be set"))
+		case api.Name == config.DefaultMetalAPIName:
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), api.Name, "name is reserved for the metal-api configured by metalURL"))
+		case names.Has(api.Name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), api.Name))
+		default:
+			for _, msg := range validation.IsDNS1123Label(api.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), api.Name, msg))
+			}
+		}
+		names.Insert(api.Name)
+
+		allErrs = append(allErrs, validateURL(api.URL, idxPath.Child("url"))...)
+
+		if api.Auth != nil {
+			allErrs = append(allErrs, validateMetalAPIAuth(api.Auth, idxPath.Child("auth"))...)
+		}
+
+		if !supportedMetalAuthTypes.Has(api.AuthType) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("authType"), api.AuthType, sets.List(supportedMetalAuthTypes)))
+		}
+
+		if len(api.Regions) == 0 && len(api.CloudProfiles) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath, "metal-api must select shoots by regions or cloud profiles"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateMetalAPICredentials ensures that a metal-api is authenticated either with the hmac or with a token,
+// a client certificate can be combined with both.
+func validateMetalAPICredentials(hmac string, auth *config.MetalAPIAuth, hmacPath, authPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var token, clientCert bool
+	if auth != nil {
+		token = auth.TokenFile != ""
+		clientCert = auth.ClientCertFile != ""
+	}
+
+	switch {
+	case hmac != "" && token:
+		allErrs = append(allErrs, field.Forbidden(authPath.Child("tokenFile"), "metal-api hmac and token must not be set both"))
+	case hmac == "" && !token && !clientCert:
+		allErrs = append(allErrs, field.Required(hmacPath, "metal-api hmac, token or client certificate must be set"))
+	}
+
+	return allErrs
+}
+
+func validateMetalAPIAuth(auth *config.MetalAPIAuth, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, f := range []struct{ name, path string }{
+		{"tokenFile", auth.TokenFile},
+		{"clientCertFile", auth.ClientCertFile},
+		{"clientKeyFile", auth.ClientKeyFile},
+		{"caFile", auth.CAFile},
+	} {
+		if f.path != "" && !filepath.IsAbs(f.path) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f.name), f.path, "path must be absolute"))
+		}
+	}
+
+	if (auth.ClientCertFile == "") != (auth.ClientKeyFile == "") {
+		allErrs = append(allErrs, field.Required(fldPath, "client certificate and key must be set both"))
+	}
+
+	return allErrs
+}
+
+func validateClusterwideNetworkPolicy(policy *config.ClusterwideNetworkPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsDNS1123Label(policy.Namespace) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), policy.Namespace, msg))
+	}
+
+	allErrs = append(allErrs, validateCIDRs(policy.CIDRs, fldPath.Child("cidrs"))...)
+	allErrs = append(allErrs, validateSyncPeriod(policy.SyncPeriod, fldPath.Child("syncPeriod"))...)
+
+	return allErrs
+}
+
+func validateNetworkPolicy(policy *config.NetworkPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := validateCIDRs(policy.CIDRs, fldPath.Child("cidrs"))
+
+	// the sync period is only used to resolve the accounting-api host again
+	if policy.Enabled && len(policy.CIDRs) == 0 {
+		allErrs = append(allErrs, validateSyncPeriod(policy.SyncPeriod, fldPath.Child("syncPeriod"))...)
+	}
+
+	return allErrs
+}
+
+func validateSyncPeriod(period *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if period == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "sync period must be set"))
+	} else if period.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, period.Duration.String(), "sync period must be positive"))
+	}
+
+	return allErrs
+}
+
+func validateMonitoring(monitoring *config.Monitoring, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !pointer.SafeDeref(monitoring.Enabled) {
+		return allErrs
+	}
+
+	if !metricNameRegex.MatchString(monitoring.LastEventSentMetric) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("lastEventSentMetric"), monitoring.LastEventSentMetric, "must be a valid metric name"))
+	}
+
+	if monitoring.NoEventsSentAlertThreshold == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("noEventsSentAlertThreshold"), "alert threshold must be set"))
+	} else if monitoring.NoEventsSentAlertThreshold.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("noEventsSentAlertThreshold"), monitoring.NoEventsSentAlertThreshold.Duration.String(), "alert threshold must be at least 1m"))
+	}
+
+	return allErrs
+}
+
+func validateCIDRs(cidrs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, fmt.Sprintf("unable to parse cidr: %s", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateURL(rawURL string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rawURL == "" {
+		return append(allErrs, field.Required(fldPath, "url must be set"))
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, rawURL, fmt.Sprintf("unable to parse url: %s", err)))
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url scheme must be http or https"))
+	}
+
+	if u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url must contain a host"))
+	}
+
+	return allErrs
+}
+
+func validateHost(host string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if host == "" {
+		return append(allErrs, field.Required(fldPath, "accounting-api host must be set"))
+	}
+
+	if net.ParseIP(host) != nil {
+		return allErrs
+	}
+
+	for _, msg := range validation.IsDNS1123Subdomain(host) {
+		allErrs = append(allErrs, field.Invalid(fldPath, host, msg))
+	}
+
+	return allErrs
+}
+
+func validatePort(port string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if port == "" {
+		return append(allErrs, field.Required(fldPath, "accounting-api port must be set"))
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, port, "port must be numeric"))
+	}
+
+	for _, msg := range validation.IsValidPortNum(p) {
+		allErrs = append(allErrs, field.Invalid(fldPath, port, msg))
+	}
+
+	return allErrs
+}
+
+// ValidateCredentials validates the credentials of the accounting configuration.
+// They are either configured inline or read from the credentials source.
+func ValidateCredentials(accounting *config.Accounting, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if usesProjectResolver(accounting, config.ProjectResolverTypeMetal) {
+		if accounting.MetalURL != "" {
+			allErrs = append(allErrs, validateMetalAPICredentials(accounting.MetalHMAC, accounting.MetalAuth, fldPath.Child("metalHMAC"), fldPath.Child("metalAuth"))...)
+		}
+
+		for i, api := range accounting.MetalAPIs {
+			idxPath := fldPath.Child("metalAPIs").Index(i)
+			allErrs = append(allErrs, validateMetalAPICredentials(api.HMAC, api.Auth, idxPath.Child("hmac"), idxPath.Child("auth"))...)
+		}
+	}
+
+	for partition, endpoint := range accounting.Partitions {
+		allErrs = append(allErrs, validateAccountingEndpointCredentials(&endpoint, fldPath.Child("partitions").Key(partition))...)
+	}
+
+	if accounting.CA == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ca"), "accounting-api ca must be set"))
+	} else if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(accounting.CA)); !ok {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), "<redacted>", "unable to parse ca certificate"))
+	}
+
+	if accounting.ClientCA != nil {
+		return append(allErrs, validateClientCA(accounting.ClientCA, fldPath.Child("clientCA"))...)
+	}
+
+	return append(allErrs, validateKeyPair(accounting.ClientCert, accounting.ClientKey, fldPath)...)
+}
+
+func validateClientCA(ca *config.ClientCA, fldPath *field.Path) field.ErrorList {
+	allErrs := validateKeyPair(ca.Certificate, ca.PrivateKey, fldPath)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	pair, _ := tls.X509KeyPair([]byte(ca.Certificate), []byte(ca.PrivateKey))
+	if cert, err := x509.ParseCertificate(pair.Certificate[0]); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cert"), "<redacted>", fmt.Sprintf("unable to parse certificate: %s", err)))
+	} else if !cert.IsCA {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cert"), "<redacted>", "certificate must be a ca certificate"))
+	}
+
+	return allErrs
+}
+
+func validateCredentialsSource(source *config.CredentialsSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case source.SecretRef == nil && source.Path == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "either secretRef or path must be set"))
+	case source.SecretRef != nil && source.Path != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of secretRef or path must be set"))
+	case source.SecretRef != nil:
+		if source.SecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secretRef", "name"), "secret name must be set"))
+		}
+		if source.SecretRef.Namespace == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secretRef", "namespace"), "secret namespace must be set"))
+		}
+	case *source.Path == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), "path must not be empty"))
+	}
+
+	return allErrs
+}
+
+func validateKeyPair(cert, key string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cert == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cert"), "certificate must be set"))
+	}
+	if key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key must be set"))
+	}
+	if cert == "" || key == "" {
+		return allErrs
+	}
+
+	if _, err := tls.X509KeyPair([]byte(cert), []byte(key)); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), "<redacted>", fmt.Sprintf("certificate and key do not form a valid key pair: %s", err)))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,591 @@
+package validation_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config/validation"
+)
+
+// fieldError is the part of a field.Error which is compared by the tests.
+type fieldError struct {
+	Type  field.ErrorType
+	Field string
+}
+
+func fieldErrors(errs field.ErrorList) []fieldError {
+	var result []fieldError
+	for _, err := range errs {
+		result = append(result, fieldError{Type: err.Type, Field: err.Field})
+	}
+	return result
+}
+
+// generateCertificate returns the certificate and the private key of a certificate, which is signed by the given ca
+// or self-signed if the ca is nil.
+func generateCertificate(t *testing.T, certType secretsutils.CertType, ca *secretsutils.Certificate) *secretsutils.Certificate {
+	t.Helper()
+
+	cert, err := (&secretsutils.CertificateSecretConfig{
+		Name:       "test",
+		CommonName: "test",
+		CertType:   certType,
+		SigningCA:  ca,
+	}).GenerateCertificate()
+	if err != nil {
+		t.Fatalf("unable to generate certificate: %s", err)
+	}
+
+	return cert
+}
+
+var (
+	certificatesOnce sync.Once
+	testCA           *secretsutils.Certificate
+	testClient       *secretsutils.Certificate
+	otherClient      *secretsutils.Certificate
+)
+
+// testCertificates generates the certificates used by the tests only once, as generating the keys is slow.
+func testCertificates(t *testing.T) (ca, client, other *secretsutils.Certificate) {
+	t.Helper()
+
+	certificatesOnce.Do(func() {
+		testCA = generateCertificate(t, secretsutils.CACert, nil)
+		testClient = generateCertificate(t, secretsutils.ClientCert, testCA)
+		otherClient = generateCertificate(t, secretsutils.ClientCert, testCA)
+	})
+
+	return testCA, testClient, otherClient
+}
+
+// validConfiguration returns a defaulted controller configuration which passes the validation.
+func validConfiguration(t *testing.T) *config.ControllerConfiguration {
+	t.Helper()
+
+	ca, client, _ := testCertificates(t)
+
+	return &config.ControllerConfiguration{
+		Accounting: config.Accounting{
+			MetalURL:        "https://metal.example.com",
+			MetalHMAC:       "hmac",
+			MetalAuthType:   "Metal-View",
+			ProjectCacheTTL: &metav1.Duration{Duration: 30 * time.Minute},
+			AccountingHost:  "accounting.example.com",
+			AccountingPort:  "9000",
+			CA:              string(ca.CertificatePEM),
+			ClientCert:      string(client.CertificatePEM),
+			ClientKey:       string(client.PrivateKeyPEM),
+			ExporterPort:    3000,
+			ExporterAvailability: config.ExporterAvailability{
+				PriorityClassName: "gardener-system-200",
+			},
+			ProjectResolver: config.ProjectResolver{
+				Type: config.ProjectResolverTypeMetal,
+			},
+		},
+		ClusterwideNetworkPolicy: config.ClusterwideNetworkPolicy{
+			Namespace:  "firewall",
+			SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		Monitoring: config.Monitoring{
+			Enabled:                    pointer.Pointer(true),
+			LastEventSentMetric:        "accounting_exporter_last_event_sent_timestamp_seconds",
+			NoEventsSentAlertThreshold: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, cfg *config.ControllerConfiguration)
+		want   []fieldError
+	}{
+		{
+			name:   "valid configuration",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {},
+		},
+		{
+			name: "missing accounting-api host and port",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.AccountingHost = ""
+				cfg.Accounting.AccountingPort = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.hostname"},
+				{Type: field.ErrorTypeRequired, Field: "accounting.port"},
+			},
+		},
+		{
+			name: "metal-api is not required by the static project resolver",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.MetalURL = ""
+				cfg.Accounting.MetalHMAC = ""
+				cfg.Accounting.ProjectResolver = config.ProjectResolver{
+					Type:   config.ProjectResolverTypeStatic,
+					Static: map[string]config.StaticProject{"p1": {Name: "project", TenantID: "tenant"}},
+				}
+			},
+		},
+		{
+			name: "static project without tenant",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver = config.ProjectResolver{
+					Type:   config.ProjectResolverTypeStatic,
+					Static: map[string]config.StaticProject{"p1": {Name: "project"}},
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.projectResolver.static[p1].tenantID"},
+			},
+		},
+		{
+			name: "unsupported project resolver",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.Type = "ldap"
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeNotSupported, Field: "accounting.projectResolver.type"},
+			},
+		},
+		{
+			name: "project resolvers per provider type",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.ProviderTypes = map[string]config.ProjectResolverType{
+					"metal":     config.ProjectResolverTypeMetal,
+					"local":     config.ProjectResolverTypeGarden,
+					"openstack": config.ProjectResolverTypeStatic,
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.projectResolver.static"},
+			},
+		},
+		{
+			name: "invalid project resolvers per provider type",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.ProviderTypes = map[string]config.ProjectResolverType{
+					"local":     config.ProjectResolverTypeMetal,
+					"openstack": "ldap",
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.projectResolver.providerTypes[local]"},
+				{Type: field.ErrorTypeNotSupported, Field: "accounting.projectResolver.providerTypes[openstack]"},
+			},
+		},
+		{
+			name: "metal-api is validated if only a provider type uses the metal project resolver",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.Type = config.ProjectResolverTypeGarden
+				cfg.Accounting.ProjectResolver.ProviderTypes = map[string]config.ProjectResolverType{"metal": config.ProjectResolverTypeMetal}
+				cfg.Accounting.ProjectCacheTTL = nil
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.projectCacheTTL"},
+			},
+		},
+		{
+			name: "project dimensions",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.Dimensions = []config.ProjectDimension{
+					{Key: "cost-center", Name: "cost_center"},
+					{Key: "contract", Name: "contract_id"},
+				}
+			},
+		},
+		{
+			name: "invalid project dimensions",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectResolver.Dimensions = []config.ProjectDimension{
+					{Key: "", Name: "cost_center"},
+					{Key: "contract", Name: "Contract-ID"},
+					{Key: "cost-center", Name: "cost_center"},
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.projectResolver.dimensions[0].key"},
+				{Type: field.ErrorTypeInvalid, Field: "accounting.projectResolver.dimensions[1].name"},
+				{Type: field.ErrorTypeDuplicate, Field: "accounting.projectResolver.dimensions[2].name"},
+			},
+		},
+		{
+			name: "invalid accounting-api host and port",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.AccountingHost = "https://accounting.example.com"
+				cfg.Accounting.AccountingPort = "70000"
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.hostname"},
+				{Type: field.ErrorTypeInvalid, Field: "accounting.port"},
+			},
+		},
+		{
+			name: "invalid accounting-api of a partition",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.Partitions = map[string]config.AccountingEndpoint{
+					"partition-b": {AccountingHost: "https://accounting-b.example.com", AccountingPort: "70000"},
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.partitions[partition-b].hostname"},
+				{Type: field.ErrorTypeInvalid, Field: "accounting.partitions[partition-b].port"},
+			},
+		},
+		{
+			name: "accounting-api host may be an ip address",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.AccountingHost = "10.0.0.1"
+			},
+		},
+		{
+			name: "invalid metal-api url and auth type",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.MetalURL = "metal.example.com"
+				cfg.Accounting.MetalAuthType = "Metal-Root"
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.metalURL"},
+				{Type: field.ErrorTypeInvalid, Field: "accounting.metalURL"},
+				{Type: field.ErrorTypeNotSupported, Field: "accounting.metalAuthType"},
+			},
+		},
+		{
+			name: "missing metal-api hmac",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.MetalHMAC = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.metalHMAC"},
+			},
+		},
+		{
+			name: "invalid metal-api auth",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.MetalAuth = &config.MetalAPIAuth{TokenFile: "token", ClientCertFile: "/etc/metal-api/tls.crt"}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.metalAuth.tokenFile"},
+				{Type: field.ErrorTypeRequired, Field: "accounting.metalAuth"},
+				{Type: field.ErrorTypeForbidden, Field: "accounting.metalAuth.tokenFile"},
+			},
+		},
+		{
+			name: "unparsable ca",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.CA = "no certificate"
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.ca"},
+			},
+		},
+		{
+			name: "client certificate and key do not match",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				_, _, other := testCertificates(t)
+				cfg.Accounting.ClientKey = string(other.PrivateKeyPEM)
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.key"},
+			},
+		},
+		{
+			name: "missing client certificate",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ClientCert = ""
+				cfg.Accounting.ClientKey = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.cert"},
+				{Type: field.ErrorTypeRequired, Field: "accounting.key"},
+			},
+		},
+		{
+			name: "missing project cache ttl and invalid exporter port",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ProjectCacheTTL = nil
+				cfg.Accounting.ExporterPort = 0
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.projectCacheTTL"},
+				{Type: field.ErrorTypeInvalid, Field: "accounting.exporterPort"},
+			},
+		},
+		{
+			name: "invalid priority class",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ExporterAvailability.PriorityClassName = "Priority"
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.exporterAvailability.priorityClassName"},
+			},
+		},
+		{
+			name: "default exporter resources exceeding the maximum",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ExporterMaxResources = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}
+				cfg.Accounting.ExporterResources = &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.exporterResources.limits[memory]"},
+			},
+		},
+		{
+			name: "invalid maximum exporter resources",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.Accounting.ExporterMaxResources = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "accounting.exporterMaxResources[cpu]"},
+			},
+		},
+		{
+			name: "invalid image pull secret",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.ImagePullSecret = &config.ImagePullSecret{DockerConfigJSON: "{not base64}"}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "imagePullSecret.encodedDockerConfigJSON"},
+			},
+		},
+		{
+			name: "network policy resolving the accounting-api host",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.NetworkPolicy = config.NetworkPolicy{Enabled: true, SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute}}
+			},
+		},
+		{
+			name: "network policy without sync period",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.NetworkPolicy = config.NetworkPolicy{Enabled: true}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "networkPolicy.syncPeriod"},
+			},
+		},
+		{
+			name: "network policy with configured cidrs does not need a sync period",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.NetworkPolicy = config.NetworkPolicy{Enabled: true, CIDRs: []string{"10.0.0.0/24"}}
+			},
+		},
+		{
+			name: "network policy with invalid cidr and sync period",
+			modify: func(t *testing.T, cfg *config.ControllerConfiguration) {
+				cfg.NetworkPolicy = config.NetworkPolicy{Enabled: true, CIDRs: []string{"10.0.0.1"}, SyncPeriod: &metav1.Duration{}}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "networkPolicy.cidrs[0]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfiguration(t)
+			tt.modify(t, cfg)
+
+			got := fieldErrors(validation.ValidateConfiguration(cfg))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateConfiguration() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateConfigurationCredentialsSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source *config.CredentialsSource
+		want   []fieldError
+	}{
+		{
+			name:   "secret reference",
+			source: &config.CredentialsSource{SecretRef: &corev1.SecretReference{Name: "credentials", Namespace: "extension-accounting"}},
+		},
+		{
+			name:   "path",
+			source: &config.CredentialsSource{Path: pointer.Pointer("/etc/credentials")},
+		},
+		{
+			name:   "neither secret reference nor path",
+			source: &config.CredentialsSource{},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.credentialsSource"},
+			},
+		},
+		{
+			name: "secret reference and path",
+			source: &config.CredentialsSource{
+				SecretRef: &corev1.SecretReference{Name: "credentials", Namespace: "extension-accounting"},
+				Path:      pointer.Pointer("/etc/credentials"),
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeForbidden, Field: "accounting.credentialsSource"},
+			},
+		},
+		{
+			name:   "incomplete secret reference",
+			source: &config.CredentialsSource{SecretRef: &corev1.SecretReference{}},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "accounting.credentialsSource.secretRef.name"},
+				{Type: field.ErrorTypeRequired, Field: "accounting.credentialsSource.secretRef.namespace"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfiguration(t)
+			// the referenced credentials are validated when they are loaded
+			cfg.Accounting.MetalHMAC = ""
+			cfg.Accounting.CA = ""
+			cfg.Accounting.MetalAPIs = []config.MetalAPI{{Name: "region-b", URL: "https://metal-b.example.com", AuthType: "Metal-View", Regions: []string{"region-b"}}}
+			cfg.Accounting.Partitions = map[string]config.AccountingEndpoint{"partition-b": {AccountingHost: "accounting-b.example.com"}}
+			cfg.Accounting.CredentialsSource = tt.source
+
+			got := fieldErrors(validation.ValidateConfiguration(cfg))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateConfiguration() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	ca, client, _ := testCertificates(t)
+
+	tests := []struct {
+		name   string
+		modify func(accounting *config.Accounting)
+		want   []fieldError
+	}{
+		{
+			name:   "valid credentials",
+			modify: func(*config.Accounting) {},
+		},
+		{
+			name: "missing hmac of the default metal-api",
+			modify: func(accounting *config.Accounting) {
+				accounting.MetalHMAC = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "credentials.metalHMAC"},
+			},
+		},
+		{
+			name: "missing hmac of a further metal-api",
+			modify: func(accounting *config.Accounting) {
+				accounting.MetalAPIs[0].HMAC = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "credentials.metalAPIs[0].hmac"},
+			},
+		},
+		{
+			name: "token instead of the hmac of the default metal-api",
+			modify: func(accounting *config.Accounting) {
+				accounting.MetalHMAC = ""
+				accounting.MetalAuth = &config.MetalAPIAuth{TokenFile: "/var/run/secrets/metal-api/token"}
+			},
+		},
+		{
+			name: "client certificate instead of the hmac of a further metal-api",
+			modify: func(accounting *config.Accounting) {
+				accounting.MetalAPIs[0].HMAC = ""
+				accounting.MetalAPIs[0].Auth = &config.MetalAPIAuth{ClientCertFile: "/etc/metal-api/tls.crt", ClientKeyFile: "/etc/metal-api/tls.key"}
+			},
+		},
+		{
+			name: "hmac and token of the default metal-api",
+			modify: func(accounting *config.Accounting) {
+				accounting.MetalAuth = &config.MetalAPIAuth{TokenFile: "/var/run/secrets/metal-api/token"}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeForbidden, Field: "credentials.metalAuth.tokenFile"},
+			},
+		},
+		{
+			name: "metal-api credentials are not required by other resolvers",
+			modify: func(accounting *config.Accounting) {
+				accounting.ProjectResolver.Type = config.ProjectResolverTypeGarden
+				accounting.MetalHMAC = ""
+				accounting.MetalAPIs[0].HMAC = ""
+			},
+		},
+		{
+			name: "metal-api credentials are required by the metal project resolver of a provider type",
+			modify: func(accounting *config.Accounting) {
+				accounting.ProjectResolver.Type = config.ProjectResolverTypeGarden
+				accounting.ProjectResolver.ProviderTypes = map[string]config.ProjectResolverType{"metal": config.ProjectResolverTypeMetal}
+				accounting.MetalHMAC = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "credentials.metalHMAC"},
+			},
+		},
+		{
+			name: "missing ca",
+			modify: func(accounting *config.Accounting) {
+				accounting.CA = ""
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeRequired, Field: "credentials.ca"},
+			},
+		},
+		{
+			name: "partition without own credentials",
+			modify: func(accounting *config.Accounting) {
+				accounting.Partitions["partition-b"] = config.AccountingEndpoint{AccountingHost: "accounting-b.example.com"}
+			},
+		},
+		{
+			name: "invalid credentials of a partition",
+			modify: func(accounting *config.Accounting) {
+				accounting.Partitions["partition-b"] = config.AccountingEndpoint{
+					AccountingHost: "accounting-b.example.com",
+					CA:             "invalid",
+					ClientCert:     string(client.CertificatePEM),
+				}
+			},
+			want: []fieldError{
+				{Type: field.ErrorTypeInvalid, Field: "credentials.partitions[partition-b].ca"},
+				{Type: field.ErrorTypeRequired, Field: "credentials.partitions[partition-b].key"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounting := validConfiguration(t).Accounting
+			accounting.MetalAPIs = []config.MetalAPI{{Name: "region-b", URL: "https://metal-b.example.com", HMAC: "hmac", AuthType: "Metal-View", Regions: []string{"region-b"}}}
+			accounting.Partitions = map[string]config.AccountingEndpoint{
+				"partition-b": {
+					AccountingHost: "accounting-b.example.com",
+					CA:             string(ca.CertificatePEM),
+					ClientCert:     string(client.CertificatePEM),
+					ClientKey:      string(client.PrivateKeyPEM),
+				},
+			}
+			tt.modify(&accounting)
+
+			got := fieldErrors(validation.ValidateCredentials(&accounting, field.NewPath("credentials")))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateCredentials() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -11,12 +11,73 @@ package config
 
 import (
 	v1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Accounting) DeepCopyInto(out *Accounting) {
 	*out = *in
+	if in.MetalAuth != nil {
+		in, out := &in.MetalAuth, &out.MetalAuth
+		*out = new(MetalAPIAuth)
+		**out = **in
+	}
+	if in.MetalAPIs != nil {
+		in, out := &in.MetalAPIs, &out.MetalAPIs
+		*out = make([]MetalAPI, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProjectCacheTTL != nil {
+		in, out := &in.ProjectCacheTTL, &out.ProjectCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProjectNotFoundCacheTTL != nil {
+		in, out := &in.ProjectNotFoundCacheTTL, &out.ProjectNotFoundCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProjectCacheStaleWhileError != nil {
+		in, out := &in.ProjectCacheStaleWhileError, &out.ProjectCacheStaleWhileError
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClientCA != nil {
+		in, out := &in.ClientCA, &out.ClientCA
+		*out = new(ClientCA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make(map[string]AccountingEndpoint, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExporterResources != nil {
+		in, out := &in.ExporterResources, &out.ExporterResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExporterMaxResources != nil {
+		in, out := &in.ExporterMaxResources, &out.ExporterMaxResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	in.ExporterVPA.DeepCopyInto(&out.ExporterVPA)
+	in.ExporterAvailability.DeepCopyInto(&out.ExporterAvailability)
+	in.ProjectResolver.DeepCopyInto(&out.ProjectResolver)
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -30,11 +91,74 @@ func (in *Accounting) DeepCopy() *Accounting {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountingEndpoint) DeepCopyInto(out *AccountingEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingEndpoint.
+func (in *AccountingEndpoint) DeepCopy() *AccountingEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountingEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCA) DeepCopyInto(out *ClientCA) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCA.
+func (in *ClientCA) DeepCopy() *ClientCA {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterwideNetworkPolicy) DeepCopyInto(out *ClusterwideNetworkPolicy) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterwideNetworkPolicy.
+func (in *ClusterwideNetworkPolicy) DeepCopy() *ClusterwideNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterwideNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.Accounting = in.Accounting
+	in.Accounting.DeepCopyInto(&out.Accounting)
 	if in.HealthCheckConfig != nil {
 		in, out := &in.HealthCheckConfig, &out.HealthCheckConfig
 		*out = new(v1alpha1.HealthCheckConfig)
@@ -45,6 +169,9 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(ImagePullSecret)
 		**out = **in
 	}
+	in.ClusterwideNetworkPolicy.DeepCopyInto(&out.ClusterwideNetworkPolicy)
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	return
 }
 
@@ -66,6 +193,86 @@ func (in *ControllerConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSource) DeepCopyInto(out *CredentialsSource) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSource.
+func (in *CredentialsSource) DeepCopy() *CredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterAvailability) DeepCopyInto(out *ExporterAvailability) {
+	*out = *in
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterAvailability.
+func (in *ExporterAvailability) DeepCopy() *ExporterAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterVPA) DeepCopyInto(out *ExporterVPA) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterVPA.
+func (in *ExporterVPA) DeepCopy() *ExporterVPA {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterVPA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
 	*out = *in
@@ -81,3 +288,169 @@ func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalAPI) DeepCopyInto(out *MetalAPI) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(MetalAPIAuth)
+		**out = **in
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudProfiles != nil {
+		in, out := &in.CloudProfiles, &out.CloudProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalAPI.
+func (in *MetalAPI) DeepCopy() *MetalAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalAPIAuth) DeepCopyInto(out *MetalAPIAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalAPIAuth.
+func (in *MetalAPIAuth) DeepCopy() *MetalAPIAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalAPIAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NoEventsSentAlertThreshold != nil {
+		in, out := &in.NoEventsSentAlertThreshold, &out.NoEventsSentAlertThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectDimension) DeepCopyInto(out *ProjectDimension) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDimension.
+func (in *ProjectDimension) DeepCopy() *ProjectDimension {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectDimension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectResolver) DeepCopyInto(out *ProjectResolver) {
+	*out = *in
+	if in.ProviderTypes != nil {
+		in, out := &in.ProviderTypes, &out.ProviderTypes
+		*out = make(map[string]ProjectResolverType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Static != nil {
+		in, out := &in.Static, &out.Static
+		*out = make(map[string]StaticProject, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]ProjectDimension, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectResolver.
+func (in *ProjectResolver) DeepCopy() *ProjectResolver {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectResolver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticProject) DeepCopyInto(out *StaticProject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticProject.
+func (in *StaticProject) DeepCopy() *StaticProject {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticProject)
+	in.DeepCopyInto(out)
+	return out
+}
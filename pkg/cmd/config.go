@@ -6,6 +6,7 @@ import (
 
 	configapi "github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
 	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config/validation"
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 
 	"github.com/spf13/pflag"
@@ -54,9 +55,9 @@ func (o *AccountingOptions) Complete() error {
 		return err
 	}
 
-	// if errs := validation.ValidateConfiguration(&config); len(errs) > 0 {
-	// 	return errs.ToAggregate()
-	// }
+	if errs := validation.ValidateConfiguration(&config); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
 
 	o.config = &AccountingServiceConfig{
 		config: config,
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"github.com/fi-ts/gardener-extension-accounting/pkg/controller"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/controller/cwnp"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/controller/healthcheck"
 	// "github.com/fi-ts/gardener-extension-accounting/pkg/webhook/kapiserver"
 	controllercmd "github.com/gardener/gardener/extensions/pkg/controller/cmd"
+	extensionshealthcheckcontroller "github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
 	webhookcmd "github.com/gardener/gardener/extensions/pkg/webhook/cmd"
 )
 
@@ -11,6 +14,8 @@ import (
 func ControllerSwitchOptions() *controllercmd.SwitchOptions {
 	return controllercmd.NewSwitchOptions(
 		controllercmd.Switch(controller.ControllerName, controller.AddToManager),
+		controllercmd.Switch(extensionshealthcheckcontroller.ControllerName, healthcheck.AddToManager),
+		controllercmd.Switch(cwnp.ControllerName, cwnp.AddToManager),
 	)
 }
 
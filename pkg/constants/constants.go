@@ -0,0 +1,6 @@
+// Package constants contains the constants of the accounting extension, which are shared by the controller
+// and the admission component without pulling in the dependencies of the controller.
+package constants
+
+// ExtensionType is the type of the Extension resource and of the extension in the shoot spec.
+const ExtensionType = "fits-accounting"
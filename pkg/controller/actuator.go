@@ -3,50 +3,76 @@ package controller
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"time"
 
 	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
 	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/endpoint"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/identity"
 	"github.com/fi-ts/gardener-extension-accounting/pkg/imagevector"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/metrics"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/resolver"
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/controllerutils"
 	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/gardener/gardener/pkg/utils"
 	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/gardener/gardener/pkg/utils/managedresources"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 	"github.com/go-logr/logr"
-	metalgo "github.com/metal-stack/metal-go"
-	"github.com/metal-stack/metal-go/api/client/project"
-	"github.com/metal-stack/metal-go/api/models"
-	"github.com/metal-stack/metal-lib/pkg/cache"
 	"github.com/metal-stack/metal-lib/pkg/pointer"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	metalhelper "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
-	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
-
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ConditionTypeProjectResolved indicates whether the project metadata of the shoot could be resolved.
+	ConditionTypeProjectResolved gardencorev1beta1.ConditionType = "ProjectResolved"
+	// ConditionTypeExporterConfigured indicates whether the accounting-exporter could be deployed.
+	ConditionTypeExporterConfigured gardencorev1beta1.ConditionType = "ExporterConfigured"
+)
+
+const (
+	accountingExporterTLSSecretName      = "accounting-exporter-tls"
+	accountingExporterRegistrySecretName = "accounting-exporter-registry-credentials"
+	shootAccessSecretName                = gutil.SecretNamePrefixShootAccess + "accounting-exporter"
+)
+
 // NewActuator returns an actuator responsible for Extension resources.
-func NewActuator(mgr manager.Manager, config config.ControllerConfiguration) extension.Actuator {
-	a := &actuator{
-		client:  mgr.GetClient(),
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
-		config:  config,
-	}
-	a.projects = cache.NewFetchAll(30*time.Minute, a.fetchAllProjects)
-	return a
+func NewActuator(mgr manager.Manager, config config.ControllerConfiguration, projectResolver resolver.ProjectResolver, credentials *credentials.Store) extension.Actuator {
+	return &actuator{
+		client:      mgr.GetClient(),
+		decoder:     serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		config:      config,
+		credentials: credentials,
+		projects:    projectResolver,
+		recorder:    mgr.GetEventRecorderFor(ControllerName),
+		clock:       clock.RealClock{},
+	}
 }
 
 type actuator struct {
@@ -54,16 +80,61 @@ type actuator struct {
 	decoder runtime.Decoder
 	config  config.ControllerConfiguration
 
-	projects *cache.FetchAllCache[string, *models.V1ProjectResponse]
+	credentials *credentials.Store
+	projects    resolver.ProjectResolver
+	recorder    record.EventRecorder
+	clock       clock.Clock
+}
+
+// currentConfig returns the controller configuration with the current credentials.
+func (a *actuator) currentConfig() config.ControllerConfiguration {
+	cc := a.config
+	a.credentials.Apply(&cc.Accounting)
+	return cc
 }
 
 // ForceDelete implements extension.Actuator.
-func (a *actuator) ForceDelete(context.Context, logr.Logger, *extensionsv1alpha1.Extension) error {
-	return nil
+func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	namespace := ex.GetNamespace()
+	defer func() { metrics.ObserveFinalOperation(namespace, "force-delete", err) }()
+
+	log.Info("force deleting managed resources for accounting")
+
+	// the shoot api server might already be gone, so the shoot objects cannot be cleaned up anymore
+	if err := managedresources.SetKeepObjects(ctx, a.client, namespace, v1alpha1.ShootAccountingResourceName, true); err != nil {
+		return err
+	}
+
+	for _, name := range []string{v1alpha1.ShootAccountingResourceName, v1alpha1.SeedAccountingResourceName} {
+		if err := managedresources.Delete(ctx, a.client, namespace, name, false); err != nil {
+			return err
+		}
+	}
+
+	// the resource manager cannot release the shoot managed resource without reaching the shoot, so we do not wait for it
+	mr := &resourcesv1alpha1.ManagedResource{ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.ShootAccountingResourceName, Namespace: namespace}}
+	if err := a.client.Get(ctx, client.ObjectKeyFromObject(mr), mr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get shoot managed resource: %w", err)
+		}
+	} else if err := controllerutils.RemoveAllFinalizers(ctx, a.client, mr); err != nil {
+		return fmt.Errorf("unable to remove finalizers from shoot managed resource: %w", err)
+	}
+
+	// the seed objects are removed by the resource manager, which does not depend on the shoot, so we do not wait for them either.
+	// No final "cluster deleted" accounting event is emitted: the extension has no client of the accounting-api and the
+	// accounting-exporter, which is the only component reporting to it, is removed together with the seed objects.
+	return a.deleteClientCertificates(ctx, namespace)
 }
 
 // Reconcile the Extension resource.
-func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	defer func() { metrics.ObserveOperation(ex.GetNamespace(), "reconcile", err) }()
+
+	return a.reconcile(ctx, log, ex)
+}
+
+func (a *actuator) reconcile(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
 	namespace := ex.GetNamespace()
 
 	cluster, err := controller.GetCluster(ctx, a.client, namespace)
@@ -76,98 +147,226 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, ex *extension
 		if _, _, err := a.decoder.Decode(ex.Spec.ProviderConfig.Raw, nil, accountingConfig); err != nil {
 			return fmt.Errorf("failed to decode provider config: %w", err)
 		}
+	} else {
+		v1alpha1.SetObjectDefaults_AccountingConfig(accountingConfig)
 	}
 
-	if err := a.createResources(ctx, log, accountingConfig, cluster, namespace); err != nil {
-		return err
+	status := &v1alpha1.AccountingStatus{}
+	if ex.Status.ProviderStatus != nil {
+		if _, _, err := a.decoder.Decode(ex.Status.ProviderStatus.Raw, nil, status); err != nil {
+			log.Error(err, "unable to decode provider status, overwriting it")
+			status = &v1alpha1.AccountingStatus{}
+		}
+	}
+	status.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "AccountingStatus"}
+
+	id, project, err := a.resolveProject(ctx, cluster)
+	if err != nil {
+		return errors.Join(err, a.updateStatus(ctx, ex, status,
+			a.updatedCondition(ex, ConditionTypeProjectResolved, err, "ProjectResolutionFailed", "", ""),
+		))
+	}
+
+	accountingAPI := endpoint.ForPartition(&a.config.Accounting, id.PartitionID)
+	status.AccountingAPIEndpoint = net.JoinHostPort(accountingAPI.AccountingHost, accountingAPI.AccountingPort)
+	status.TenantID = project.TenantID
+	status.ProjectID = project.ID
+	status.ProjectName = project.Name
+	status.MetalAPI = project.MetalAPI
+	// the accounting-exporter cannot attach the dimensions to its events yet, they are only reported in the status
+	status.Dimensions = project.Dimensions
+	projectResolved := a.updatedCondition(ex, ConditionTypeProjectResolved, nil, "", "ProjectResolved", fmt.Sprintf("project %q of tenant %q was resolved", project.Name, project.TenantID))
+
+	hibernated := controller.IsHibernated(cluster)
+	if hibernationTransition(status, hibernated) {
+		a.startHibernationTransition(ex, status, hibernated)
+	}
+	status.Hibernated = hibernated
+
+	image, err := a.createResources(ctx, log, accountingConfig, id, project, cluster, status, namespace)
+	if err != nil {
+		return errors.Join(err, a.updateStatus(ctx, ex, status,
+			projectResolved,
+			a.updatedCondition(ex, ConditionTypeExporterConfigured, err, "ExporterConfigurationFailed", "", ""),
+		))
+	}
+
+	if status.HibernationTransitionPending {
+		if err := a.completeWakeUp(ctx, ex, status); err != nil {
+			return errors.Join(err, a.updateStatus(ctx, ex, status,
+				projectResolved,
+				a.updatedCondition(ex, ConditionTypeExporterConfigured, err, "ExporterNotAvailable", "", ""),
+			))
+		}
+	}
+
+	status.ExporterImage = image
+	status.LastSuccessfulReconcileTime = pointer.Pointer(metav1.NewTime(a.clock.Now()))
+
+	return a.updateStatus(ctx, ex, status,
+		projectResolved,
+		a.updatedCondition(ex, ConditionTypeExporterConfigured, nil, "", "ExporterConfigured", "accounting-exporter was configured"),
+	)
+}
+
+// updateStatus writes the accounting status and the given conditions to the extension.
+func (a *actuator) updateStatus(ctx context.Context, ex *extensionsv1alpha1.Extension, status *v1alpha1.AccountingStatus, conditions ...gardencorev1beta1.Condition) error {
+	patch := client.MergeFrom(ex.DeepCopy())
+
+	ex.Status.ProviderStatus = &runtime.RawExtension{Object: status}
+	ex.Status.Conditions = v1beta1helper.MergeConditions(ex.Status.Conditions, conditions...)
+
+	if err := a.client.Status().Patch(ctx, ex, patch); err != nil {
+		return fmt.Errorf("unable to update extension status: %w", err)
 	}
 
 	return nil
 }
 
+// updatedCondition returns the condition of the given type, which is false with the failure reason if err is set and true otherwise.
+func (a *actuator) updatedCondition(ex *extensionsv1alpha1.Extension, conditionType gardencorev1beta1.ConditionType, err error, failureReason, successReason, successMessage string) gardencorev1beta1.Condition {
+	condition := v1beta1helper.GetOrInitConditionWithClock(a.clock, ex.Status.Conditions, conditionType)
+	if err != nil {
+		return v1beta1helper.UpdatedConditionWithClock(a.clock, condition, gardencorev1beta1.ConditionFalse, failureReason, err.Error())
+	}
+
+	return v1beta1helper.UpdatedConditionWithClock(a.clock, condition, gardencorev1beta1.ConditionTrue, successReason, successMessage)
+}
+
 // Delete the Extension resource.
-func (a *actuator) Delete(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
-	return a.deleteResources(ctx, log, ex.GetNamespace())
+func (a *actuator) Delete(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	defer func() { metrics.ObserveFinalOperation(ex.GetNamespace(), "delete", err) }()
+
+	if err := a.deleteResources(ctx, log, ex.GetNamespace()); err != nil {
+		return err
+	}
+
+	return a.deleteClientCertificates(ctx, ex.GetNamespace())
 }
 
 // Restore the Extension resource.
-func (a *actuator) Restore(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
-	return a.Reconcile(ctx, log, ex)
+func (a *actuator) Restore(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	defer func() { metrics.ObserveOperation(ex.GetNamespace(), "restore", err) }()
+
+	// the shoot objects were kept during the migration, they are adopted by the managed resources again
+	// and the exporter is only started after the source seed has scaled it down
+	return a.reconcile(ctx, log, ex)
 }
 
 // Migrate the Extension resource.
-func (a *actuator) Migrate(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *actuator) Migrate(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
+	namespace := ex.GetNamespace()
+	defer func() { metrics.ObserveFinalOperation(namespace, "migrate", err) }()
+
+	for _, name := range []string{v1alpha1.ShootAccountingResourceName, v1alpha1.SeedAccountingResourceName} {
+		if err := managedresources.SetKeepObjects(ctx, a.client, namespace, name, true); err != nil {
+			return err
+		}
+	}
+
+	if err := a.deleteResources(ctx, log, namespace); err != nil {
+		return err
+	}
+
+	// the managed resources released the ownership, so the exporter can be scaled down without being reverted.
+	// it must not report anymore as soon as the destination seed takes over the cluster.
+	log.Info("scaling down accounting-exporter for migration")
+
+	key := client.ObjectKey{Namespace: namespace, Name: v1alpha1.AccountingExporterName}
+	if err := kubernetesutils.ScaleDeployment(ctx, a.client, key, 0); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to scale down accounting-exporter: %w", err)
+		}
+	} else if err := kubernetesutils.WaitUntilDeploymentScaledToDesiredReplicas(ctx, a.client, key, 0); err != nil {
+		return fmt.Errorf("error waiting for accounting-exporter to be scaled down: %w", err)
+	}
+
+	// the kept objects are removed with the shoot namespace of the source seed once the migration is completed
 	return nil
 }
 
-func (a *actuator) createResources(ctx context.Context, log logr.Logger, _ *v1alpha1.AccountingConfig, cluster *controller.Cluster, namespace string) error {
-	shootAccessSecret := gutil.NewShootAccessSecret(gutil.SecretNamePrefixShootAccess+"accounting-exporter", namespace)
-	if err := shootAccessSecret.Reconcile(ctx, a.client); err != nil {
-		return err
+// resolveProject returns the identity of the shoot, which is determined by its provider type, and the metadata of its project.
+func (a *actuator) resolveProject(ctx context.Context, cluster *controller.Cluster) (*identity.Identity, *resolver.Project, error) {
+	id, err := identity.New(cluster.Shoot.Spec.Provider.Type, a.decoder).Resolve(cluster)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	infrastructureConfig := &metalv1alpha1.InfrastructureConfig{}
-	err := metalhelper.DecodeRawExtension(cluster.Shoot.Spec.Provider.InfrastructureConfig, infrastructureConfig, a.decoder)
+	project, err := a.projects.Resolve(ctx, cluster, id.ProjectID)
 	if err != nil {
-		return fmt.Errorf("unable decoding infrastructure config: %w", err)
+		return nil, nil, fmt.Errorf("error resolving cluster project: %w", err)
+	}
+
+	return id, project, nil
+}
+
+// createResources deploys the accounting components and returns the image of the deployed accounting-exporter.
+func (a *actuator) createResources(ctx context.Context, log logr.Logger, accountingConfig *v1alpha1.AccountingConfig, id *identity.Identity, project *resolver.Project, cluster *controller.Cluster, status *v1alpha1.AccountingStatus, namespace string) (string, error) {
+	shootAccessSecret := gutil.NewShootAccessSecret(shootAccessSecretName, namespace)
+	if err := shootAccessSecret.Reconcile(ctx, a.client); err != nil {
+		return "", err
 	}
 
-	resp, err := a.projects.Get(ctx, infrastructureConfig.ProjectID)
+	accountingExporterImage, err := imagevector.ImageVector().FindImage("accounting-exporter")
 	if err != nil {
-		return fmt.Errorf("error fetching cluster project from metal-api: %w", err)
+		return "", fmt.Errorf("failed to find accounting-exporter image: %w", err)
+	}
+	image := accountingExporterImage.String()
+
+	var clientCertSecret *corev1.Secret
+	cc := a.currentConfig()
+	accountingAPI := endpoint.ForPartition(&cc.Accounting, id.PartitionID)
+
+	// the shoots of a partition with an own client certificate do not need an issued one
+	if cc.Accounting.ClientCA != nil && accountingAPI.ClientCert == "" {
+		clientCertSecret, err = a.reconcileClientCertificate(ctx, log, cc.Accounting.ClientCA, cluster, namespace)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var apiCIDRs []string
+	if cc.NetworkPolicy.Enabled {
+		apiCIDRs, err = accountingAPICIDRs(ctx, &cc, accountingAPI.AccountingHost)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	shootObjects := shootObjects()
 
-	seedObjects, err := seedObjects(&a.config, infrastructureConfig, resp, cluster, namespace, shootAccessSecret.Secret.Name)
+	seedObjects, err := seedObjects(&cc, accountingConfig, id, project, image, cluster, status, namespace, shootAccessSecret.Secret.Name, clientCertSecret, apiCIDRs)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	shootResources, err := managedresources.NewRegistry(kubernetes.ShootScheme, kubernetes.ShootCodec, kubernetes.ShootSerializer).AddAllAndSerialize(shootObjects...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	seedResources, err := managedresources.NewRegistry(kubernetes.SeedScheme, kubernetes.SeedCodec, kubernetes.SeedSerializer).AddAllAndSerialize(seedObjects...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	start := time.Now()
 	if err := managedresources.CreateForShoot(ctx, a.client, namespace, v1alpha1.ShootAccountingResourceName, "fits-accounting", false, shootResources); err != nil {
-		return err
+		return "", err
 	}
+	metrics.ManagedResourceApplyDuration.WithLabelValues(v1alpha1.ShootAccountingResourceName).Observe(time.Since(start).Seconds())
 
 	log.Info("managed resource created successfully", "name", v1alpha1.ShootAccountingResourceName)
 
+	start = time.Now()
 	if err := managedresources.CreateForSeed(ctx, a.client, namespace, v1alpha1.SeedAccountingResourceName, false, seedResources); err != nil {
-		return err
+		return "", err
 	}
+	metrics.ManagedResourceApplyDuration.WithLabelValues(v1alpha1.SeedAccountingResourceName).Observe(time.Since(start).Seconds())
 
 	log.Info("managed resource created successfully", "name", v1alpha1.SeedAccountingResourceName)
 
-	return nil
-}
-
-func (a *actuator) fetchAllProjects(ctx context.Context) (map[string]*models.V1ProjectResponse, error) {
-	// we need to lookup the project name from the metal-api
-	// unfortunately we do not have it anywhere in the cluster spec
-	mclient, err := metalgo.NewDriver(a.config.Accounting.MetalURL, "", a.config.Accounting.MetalHMAC, metalgo.AuthType(a.config.Accounting.MetalAuthType))
-	if err != nil {
-		return nil, fmt.Errorf("error creating metal client: %w", err)
-	}
-
-	projects, err := mclient.Project().ListProjects(project.NewListProjectsParams().WithContext(ctx), nil)
-	if err != nil {
-		return nil, fmt.Errorf("error fetching projects from metal-api: %w", err)
-	}
-
-	result := make(map[string]*models.V1ProjectResponse)
-	for _, p := range projects.Payload {
-		result[p.Meta.ID] = p
-	}
-
-	return result, nil
+	return image, nil
 }
 
 func (a *actuator) deleteResources(ctx context.Context, log logr.Logger, namespace string) error {
@@ -195,20 +394,18 @@ func (a *actuator) deleteResources(ctx context.Context, log logr.Logger, namespa
 	return nil
 }
 
-func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metalv1alpha1.InfrastructureConfig, project *models.V1ProjectResponse, cluster *controller.Cluster, namespace, shootAccessSecretName string) ([]client.Object, error) {
-	accountingExporterImage, err := imagevector.ImageVector().FindImage("accounting-exporter")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find accounting-exporter image: %w", err)
-	}
-
+func seedObjects(cc *config.ControllerConfiguration, accountingConfig *v1alpha1.AccountingConfig, id *identity.Identity, project *resolver.Project, accountingExporterImage string, cluster *controller.Cluster, status *v1alpha1.AccountingStatus, namespace, shootAccessSecretName string, clientCertSecret *corev1.Secret, apiCIDRs []string) ([]client.Object, error) {
 	replicas := int32(1)
-	if controller.IsHibernated(cluster) {
+	if status.Hibernated {
 		replicas = 0
 	}
 
+	resources := exporterResources(cc, accountingConfig)
+	accountingAPI := endpoint.ForPartition(&cc.Accounting, id.PartitionID)
+
 	accountingExporterDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "accounting-exporter",
+			Name:      v1alpha1.AccountingExporterName,
 			Namespace: namespace,
 			Labels: map[string]string{
 				"k8s-app": "accounting-exporter",
@@ -232,20 +429,35 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 						"networking.gardener.cloud/to-public-networks":                  "allowed",
 						"networking.resources.gardener.cloud/to-kube-apiserver-tcp-443": "allowed",
 					},
-					Annotations: map[string]string{
-						"scheduler.alpha.kubernetes.io/critical-pod": "",
-					},
 				},
 				Spec: corev1.PodSpec{
+					PriorityClassName: cc.Accounting.ExporterAvailability.PriorityClassName,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: pointer.Pointer(true),
+						RunAsUser:    pointer.Pointer(int64(65534)),
+						RunAsGroup:   pointer.Pointer(int64(65534)),
+						FSGroup:      pointer.Pointer(int64(65534)),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
 					Containers: []corev1.Container{
 						{
 							Name:            "accounting-exporter",
-							Image:           accountingExporterImage.String(),
+							Image:           accountingExporterImage,
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							Resources:       resources,
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: pointer.Pointer(false),
+								ReadOnlyRootFilesystem:   pointer.Pointer(true),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "health",
-									ContainerPort: 3000,
+									ContainerPort: cc.Accounting.ExporterPort,
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -272,7 +484,7 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 							Env: []corev1.EnvVar{
 								{
 									Name:  "KUBE_COUNTER_BIND_ADDR",
-									Value: "0.0.0.0",
+									Value: net.JoinHostPort("0.0.0.0", strconv.Itoa(int(cc.Accounting.ExporterPort))),
 								},
 								{
 									Name:  "KUBE_COUNTER_KUBECONFIG",
@@ -280,7 +492,7 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 								},
 								{
 									Name:  "KUBE_COUNTER_PARTITION",
-									Value: infrastructureConfig.PartitionID,
+									Value: id.PartitionID,
 								},
 								{
 									Name:  "KUBE_COUNTER_TENANT",
@@ -288,7 +500,7 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 								},
 								{
 									Name:  "KUBE_COUNTER_PROJECT_ID",
-									Value: infrastructureConfig.ProjectID,
+									Value: id.ProjectID,
 								},
 								{
 									Name:  "KUBE_COUNTER_PROJECT_NAME",
@@ -304,15 +516,15 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 								},
 								{
 									Name:  "KUBE_COUNTER_ACCOUNTING_API_HOSTNAME",
-									Value: cc.Accounting.AccountingHost,
+									Value: accountingAPI.AccountingHost,
 								},
 								{
 									Name:  "KUBE_COUNTER_ACCOUNTING_API_PORT",
-									Value: cc.Accounting.AccountingPort,
+									Value: accountingAPI.AccountingPort,
 								},
 								{
 									Name:  "KUBE_COUNTER_NETWORK_TRAFFIC_ENABLED",
-									Value: strconv.FormatBool(true),
+									Value: strconv.FormatBool(pointer.SafeDeref(accountingConfig.NetworkTrafficEnabled)),
 								},
 							},
 							VolumeMounts: []corev1.VolumeMount{
@@ -320,6 +532,10 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 									MountPath: "/certs",
 									Name:      "certs",
 								},
+								{
+									MountPath: "/tmp",
+									Name:      "tmp",
+								},
 							},
 						},
 					},
@@ -327,11 +543,26 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 						{
 							Name: "certs",
 							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: "accounting-exporter-tls",
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: []corev1.VolumeProjection{
+										{
+											Secret: &corev1.SecretProjection{
+												LocalObjectReference: corev1.LocalObjectReference{
+													Name: accountingExporterTLSSecretName,
+												},
+											},
+										},
+									},
 								},
 							},
 						},
+						{
+							// the root filesystem is read-only
+							Name: "tmp",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
 					},
 				},
 			},
@@ -342,20 +573,81 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 		return nil, err
 	}
 
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      accountingExporterTLSSecretName,
+			Namespace: namespace,
+		},
+		StringData: map[string]string{
+			"ca.pem": accountingAPI.CA,
+			"d":      "a bug with trailing dashes",
+		},
+	}
+
+	if clientCertSecret != nil {
+		// the client certificate of the shoot is issued by the secrets manager
+		certs := accountingExporterDeployment.Spec.Template.Spec.Volumes[0].Projected
+		certs.Sources = append(certs.Sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: clientCertSecret.Name,
+				},
+				Items: []corev1.KeyToPath{
+					{Key: secretsutils.DataKeyCertificate, Path: "client.pem"},
+					{Key: secretsutils.DataKeyPrivateKey, Path: "client-key.pem"},
+				},
+			},
+		})
+	} else {
+		tlsSecret.StringData["client.pem"] = accountingAPI.ClientCert
+		tlsSecret.StringData["client-key.pem"] = accountingAPI.ClientKey
+	}
+
 	objects := []client.Object{
 		accountingExporterDeployment,
-		&corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "accounting-exporter-tls",
-				Namespace: namespace,
-			},
-			StringData: map[string]string{
-				"ca.pem":         cc.Accounting.CA,
-				"client.pem":     cc.Accounting.ClientCert,
-				"client-key.pem": cc.Accounting.ClientKey,
-				"d":              "a bug with trailing dashes",
-			},
-		},
+		tlsSecret,
+	}
+
+	if cc.NetworkPolicy.Enabled {
+		networkPolicy, err := accountingExporterNetworkPolicy(cc, namespace, accountingAPI.AccountingPort, apiCIDRs)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, networkPolicy)
+		delete(accountingExporterDeployment.Spec.Template.Labels, v1beta1constants.LabelNetworkPolicyToPublicNetworks)
+	}
+
+	if pointer.SafeDeref(cc.Accounting.ExporterAvailability.HighAvailability) && v1beta1helper.IsHAControlPlaneConfigured(cluster.Shoot) {
+		// the resource-manager applies the zone pinning, the topology spread constraints and the toleration seconds,
+		// the accounting-exporter does not elect a leader and would send the events multiple times with more replicas
+		metav1.SetMetaDataLabel(&accountingExporterDeployment.ObjectMeta, resourcesv1alpha1.HighAvailabilityConfigType, resourcesv1alpha1.HighAvailabilityConfigTypeController)
+		metav1.SetMetaDataAnnotation(&accountingExporterDeployment.ObjectMeta, resourcesv1alpha1.HighAvailabilityConfigReplicas, "1")
+	}
+
+	if pointer.SafeDeref(cc.Accounting.ExporterAvailability.PodDisruptionBudget) {
+		objects = append(objects, accountingExporterPodDisruptionBudget(namespace))
+	}
+
+	if pointer.SafeDeref(cc.Accounting.ExporterVPA.Enabled) {
+		objects = append(objects, accountingExporterVPA(cc, namespace, resources))
+	}
+
+	if pointer.SafeDeref(cc.Monitoring.Enabled) {
+		monitoringObjects, err := monitoringObjects(cc, namespace, status.Hibernated)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, monitoringObjects...)
+	}
+
+	// the secrets whose content is read by the accounting-exporter, the keys are stable across rotations
+	referencedSecrets := map[string]*corev1.Secret{
+		accountingExporterTLSSecretName: tlsSecret,
+	}
+	if clientCertSecret != nil {
+		referencedSecrets[clientCertSecretName] = clientCertSecret
 	}
 
 	if cc.ImagePullSecret != nil && cc.ImagePullSecret.DockerConfigJSON != "" {
@@ -364,9 +656,9 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 			return nil, fmt.Errorf("unable to decode image pull secret: %w", err)
 		}
 
-		objects = append(objects, &corev1.Secret{
+		registrySecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "accounting-exporter-registry-credentials",
+				Name:      accountingExporterRegistrySecretName,
 				Namespace: namespace,
 				Labels: map[string]string{
 					"app": "accounting-exporter-registry-credentials",
@@ -376,16 +668,37 @@ func seedObjects(cc *config.ControllerConfiguration, infrastructureConfig *metal
 			Data: map[string][]byte{
 				".dockerconfigjson": content,
 			},
-		})
+		}
+
+		objects = append(objects, registrySecret)
+		referencedSecrets[accountingExporterRegistrySecretName] = registrySecret
 
 		accountingExporterDeployment.Spec.Template.Spec.ImagePullSecrets = append(accountingExporterDeployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{
-			Name: "accounting-exporter-registry-credentials",
+			Name: accountingExporterRegistrySecretName,
 		})
 	}
 
+	// the accounting-exporter only reads its credentials on startup, so it is rolled when one of its secrets changes
+	for name, secret := range referencedSecrets {
+		metav1.SetMetaDataAnnotation(&accountingExporterDeployment.Spec.Template.ObjectMeta, "checksum/secret-"+name, secretChecksum(secret))
+	}
+
 	return objects, nil
 }
 
+// secretChecksum computes the checksum of the data of the given secret, including the data which is not yet encoded.
+func secretChecksum(secret *corev1.Secret) string {
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+
+	return utils.ComputeSecretChecksum(data)
+}
+
 func shootObjects() []client.Object {
 	return []client.Object{
 		&rbacv1.ClusterRole{
@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/identity"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/resolver"
+)
+
+const testNamespace = "shoot--test--test"
+
+// testObjects returns the objects which are present in the shoot namespace of a reconciled extension.
+func testObjects(shootFinalizers []string) []client.Object {
+	return []client.Object{
+		&resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       v1alpha1.ShootAccountingResourceName,
+				Namespace:  testNamespace,
+				Finalizers: shootFinalizers,
+			},
+		},
+		&resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1alpha1.SeedAccountingResourceName,
+				Namespace: testNamespace,
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1alpha1.AccountingExporterName,
+				Namespace: testNamespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Pointer(int32(1)),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "accounting-exporter-client-1234",
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					secretsmanager.LabelKeyManagedBy:       secretsmanager.LabelValueSecretsManager,
+					secretsmanager.LabelKeyManagerIdentity: secretsManagerIdentity,
+				},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      accountingExporterTLSSecretName,
+				Namespace: testNamespace,
+			},
+		},
+	}
+}
+
+func newTestActuator(t *testing.T, objects ...client.Object) *actuator {
+	t.Helper()
+
+	// the client uses the scheme of the manager, so that the actuator only relies on the registered types
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to create scheme: %s", err)
+	}
+
+	return &actuator{
+		client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		recorder: record.NewFakeRecorder(10),
+		clock:    testclock.NewFakeClock(metav1.Now().Time),
+	}
+}
+
+func exists(t *testing.T, c client.Client, obj client.Object) bool {
+	t.Helper()
+
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		t.Fatalf("unable to get %s: %s", obj.GetName(), err)
+	}
+
+	return true
+}
+
+func TestActuatorMigrateAndForceDelete(t *testing.T) {
+	ex := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{Name: "accounting", Namespace: testNamespace},
+	}
+
+	tests := []struct {
+		name      string
+		operation func(a *actuator) error
+		// the resource manager cannot release the shoot managed resource if the shoot is not reachable anymore
+		shootFinalizers []string
+		wantReplicas    int32
+		wantClientCrt   bool
+	}{
+		{
+			name: "migrate keeps the objects and scales down the exporter",
+			operation: func(a *actuator) error {
+				return a.Migrate(context.Background(), logr.Discard(), ex)
+			},
+			wantReplicas:  0,
+			wantClientCrt: true,
+		},
+		{
+			name: "force delete removes the managed resources and the client certificates",
+			operation: func(a *actuator) error {
+				return a.ForceDelete(context.Background(), logr.Discard(), ex)
+			},
+			shootFinalizers: []string{"resources.gardener.cloud/gardener-resource-manager"},
+			wantReplicas:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestActuator(t, testObjects(tt.shootFinalizers)...)
+
+			if err := tt.operation(a); err != nil {
+				t.Fatalf("operation failed: %s", err)
+			}
+
+			for _, name := range []string{v1alpha1.ShootAccountingResourceName, v1alpha1.SeedAccountingResourceName} {
+				if exists(t, a.client, &resourcesv1alpha1.ManagedResource{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace}}) {
+					t.Errorf("managed resource %s was not deleted", name)
+				}
+			}
+
+			// the fake client has no resource manager, so the exporter is only removed by the managed resource in a real seed
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.AccountingExporterName, Namespace: testNamespace}}
+			if !exists(t, a.client, deployment) {
+				t.Fatalf("exporter was deleted")
+			}
+			if got := *deployment.Spec.Replicas; got != tt.wantReplicas {
+				t.Errorf("exporter replicas = %d, want %d", got, tt.wantReplicas)
+			}
+
+			if got := exists(t, a.client, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "accounting-exporter-client-1234", Namespace: testNamespace}}); got != tt.wantClientCrt {
+				t.Errorf("client certificate exists = %t, want %t", got, tt.wantClientCrt)
+			}
+			if !exists(t, a.client, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: accountingExporterTLSSecretName, Namespace: testNamespace}}) {
+				t.Errorf("secret %s which is not issued by the secrets manager was deleted", accountingExporterTLSSecretName)
+			}
+		})
+	}
+}
+
+func TestActuatorForceDeleteDoesNotWait(t *testing.T) {
+	ex := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{Name: "accounting", Namespace: testNamespace},
+	}
+
+	objects := testObjects(nil)
+	// the resource manager releases the seed managed resource once it removed the seed objects
+	objects[1].SetFinalizers([]string{"resources.gardener.cloud/gardener-resource-manager"})
+
+	a := newTestActuator(t, objects...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := a.ForceDelete(ctx, logr.Discard(), ex); err != nil {
+		t.Fatalf("ForceDelete() error = %s", err)
+	}
+
+	mr := &resourcesv1alpha1.ManagedResource{ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.SeedAccountingResourceName, Namespace: testNamespace}}
+	if exists(t, a.client, mr) && mr.DeletionTimestamp == nil {
+		t.Errorf("seed managed resource was not deleted")
+	}
+	if exists(t, a.client, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "accounting-exporter-client-1234", Namespace: testNamespace}}) {
+		t.Errorf("client certificate was not deleted")
+	}
+}
+
+func TestSeedObjectsExporterPort(t *testing.T) {
+	cc := &config.ControllerConfiguration{
+		Accounting: config.Accounting{AccountingHost: "accounting.example.com", AccountingPort: "9000", ExporterPort: 3001},
+	}
+	shoot := &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "garden-test"}}
+
+	objects, err := seedObjects(cc, &v1alpha1.AccountingConfig{}, &identity.Identity{PartitionID: "partition-a", ProjectID: "p1"},
+		&resolver.Project{ID: "p1", Name: "project", TenantID: "tenant"}, "accounting-exporter:latest",
+		&controller.Cluster{Shoot: shoot}, &v1alpha1.AccountingStatus{}, testNamespace, "shoot-access-accounting-exporter", nil, nil)
+	if err != nil {
+		t.Fatalf("seedObjects() error = %s", err)
+	}
+
+	for _, obj := range objects {
+		d, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		container := d.Spec.Template.Spec.Containers[0]
+		if got := container.Ports[0].ContainerPort; got != 3001 {
+			t.Errorf("container port = %d, want 3001", got)
+		}
+		for _, e := range container.Env {
+			if e.Name == "KUBE_COUNTER_BIND_ADDR" && e.Value != "0.0.0.0:3001" {
+				t.Errorf("bind address = %q, want the exporter port", e.Value)
+			}
+		}
+	}
+}
+
+func TestActuatorUpdatedCondition(t *testing.T) {
+	a := newTestActuator(t)
+	now := metav1.NewTime(a.clock.Now())
+
+	tests := []struct {
+		name       string
+		conditions []gardencorev1beta1.Condition
+		err        error
+		want       gardencorev1beta1.Condition
+	}{
+		{
+			name: "successful condition",
+			want: gardencorev1beta1.Condition{
+				Type:               ConditionTypeProjectResolved,
+				Status:             gardencorev1beta1.ConditionTrue,
+				Reason:             "ProjectResolved",
+				Message:            "project was resolved",
+				LastTransitionTime: now,
+				LastUpdateTime:     now,
+			},
+		},
+		{
+			name: "failed condition",
+			err:  errors.New("metal-api is not reachable"),
+			want: gardencorev1beta1.Condition{
+				Type:               ConditionTypeProjectResolved,
+				Status:             gardencorev1beta1.ConditionFalse,
+				Reason:             "ProjectResolutionFailed",
+				Message:            "metal-api is not reachable",
+				LastTransitionTime: now,
+				LastUpdateTime:     now,
+			},
+		},
+		{
+			name: "unchanged condition is kept",
+			conditions: []gardencorev1beta1.Condition{{
+				Type:               ConditionTypeProjectResolved,
+				Status:             gardencorev1beta1.ConditionTrue,
+				Reason:             "ProjectResolved",
+				Message:            "project was resolved",
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+				LastUpdateTime:     metav1.NewTime(now.Add(-time.Hour)),
+			}},
+			want: gardencorev1beta1.Condition{
+				Type:               ConditionTypeProjectResolved,
+				Status:             gardencorev1beta1.ConditionTrue,
+				Reason:             "ProjectResolved",
+				Message:            "project was resolved",
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+				LastUpdateTime:     metav1.NewTime(now.Add(-time.Hour)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &extensionsv1alpha1.Extension{
+				Status: extensionsv1alpha1.ExtensionStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{Conditions: tt.conditions},
+				},
+			}
+
+			got := a.updatedCondition(ex, ConditionTypeProjectResolved, tt.err, "ProjectResolutionFailed", "ProjectResolved", "project was resolved")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("updatedCondition() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecretChecksum(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("cert")}}
+
+	tests := []struct {
+		name      string
+		secret    *corev1.Secret
+		wantEqual bool
+	}{
+		{
+			name:      "same data",
+			secret:    &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("cert")}},
+			wantEqual: true,
+		},
+		{
+			name: "same data which is not yet encoded",
+			secret: &corev1.Secret{
+				Data:       map[string][]byte{"ca.crt": []byte("ca")},
+				StringData: map[string]string{"tls.crt": "cert"},
+			},
+			wantEqual: true,
+		},
+		{
+			name:   "changed data",
+			secret: &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("renewed")}},
+		},
+		{
+			name:   "changed data which is not yet encoded",
+			secret: &corev1.Secret{StringData: map[string]string{"ca.crt": "ca", "tls.crt": "renewed"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := secretChecksum(tt.secret) == secretChecksum(secret); got != tt.wantEqual {
+				t.Errorf("checksums equal = %t, want %t", got, tt.wantEqual)
+			}
+		})
+	}
+}
@@ -2,22 +2,38 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/constants"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/metrics"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/resolver"
 )
 
 const (
 	// Type is the type of Extension resource.
-	Type = "fits-accounting"
+	Type = constants.ExtensionType
 	// ControllerName is the name of the registry cache service controller.
 	ControllerName = "fits-accounting"
 	// FinalizerSuffix is the finalizer suffix for the registry cache service controller.
 	FinalizerSuffix = "fits-accounting"
+
+	// clientCertificateResync is the interval in which the extensions are reconciled when the client certificates
+	// are issued by the extension, such that they are renewed before they expire.
+	clientCertificateResync = time.Hour
 )
 
 var (
@@ -35,6 +51,8 @@ type AddOptions struct {
 	IgnoreOperationAnnotation bool
 	// ExtensionClass defines the extension class this extension is responsible for.
 	ExtensionClass extensionsv1alpha1.ExtensionClass
+	// GardenCluster is the garden cluster, it is used for resolving project metadata from the garden.
+	GardenCluster cluster.Cluster
 }
 
 // AddToManager adds a controller with the default Options to the given Controller Manager.
@@ -45,14 +63,89 @@ func AddToManager(ctx context.Context, mgr manager.Manager) error {
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
+	var gardenClient client.Client
+	if opts.GardenCluster != nil {
+		gardenClient = opts.GardenCluster.GetClient()
+	}
+
+	// the credentials secret is watched without a cache, as it is a single secret which is not necessarily in a namespace of the manager cache
+	credentialsClient, err := client.NewWithWatch(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return fmt.Errorf("unable to create credentials client: %w", err)
+	}
+
+	creds := credentials.NewStore(opts.Config.Accounting, credentialsClient, func(ctx context.Context) error {
+		return reconcileAll(ctx, mgr.GetClient(), opts.ExtensionClass)
+	})
+	if _, err := creds.Load(ctx); err != nil {
+		return fmt.Errorf("unable to load credentials: %w", err)
+	}
+	if err := mgr.Add(creds); err != nil {
+		return fmt.Errorf("unable to add credentials reloader to manager: %w", err)
+	}
+
+	projectResolver, err := resolver.New(&opts.Config.Accounting, gardenClient, creds)
+	if err != nil {
+		return fmt.Errorf("unable to create project resolver: %w", err)
+	}
+
+	decoder := serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder()
+	if err := metrics.RegisterHibernatedExporters(func(ctx context.Context) (int, error) {
+		return hibernatedExporters(ctx, mgr.GetClient(), decoder, opts.ExtensionClass)
+	}); err != nil {
+		return fmt.Errorf("unable to register hibernated exporters metric: %w", err)
+	}
+
 	return extension.Add(mgr, extension.AddArgs{
-		Actuator:          NewActuator(mgr, opts.Config),
+		Actuator:          NewActuator(mgr, opts.Config, projectResolver, creds),
 		ControllerOptions: opts.ControllerOptions,
 		Name:              ControllerName,
 		FinalizerSuffix:   FinalizerSuffix,
-		Resync:            0,
+		Resync:            resyncPeriod(&opts.Config),
 		Predicates:        extension.DefaultPredicates(ctx, mgr, DefaultAddOptions.IgnoreOperationAnnotation),
 		Type:              Type,
 		ExtensionClasses:  []extensionsv1alpha1.ExtensionClass{opts.ExtensionClass},
 	})
 }
+
+// resyncPeriod returns the interval in which the extensions are reconciled without a change, it is zero if they do not
+// need to be reconciled periodically.
+func resyncPeriod(cc *config.ControllerConfiguration) time.Duration {
+	var resync time.Duration
+	if cc.Accounting.ClientCA != nil {
+		resync = clientCertificateResync
+	}
+	// the addresses of the accounting-api hosts are resolved again on every reconciliation of the network policies
+	if policy := cc.NetworkPolicy; policy.Enabled && len(policy.CIDRs) == 0 && (resync == 0 || policy.SyncPeriod.Duration < resync) {
+		resync = policy.SyncPeriod.Duration
+	}
+
+	return resync
+}
+
+// reconcileAll requests the reconciliation of all extensions of this type and the given class, e.g. to roll out changed credentials.
+func reconcileAll(ctx context.Context, c client.Client, class extensionsv1alpha1.ExtensionClass) error {
+	// the controller is responsible for shoot extensions if no class is configured, see predicate.HasClass
+	if class == "" {
+		class = extensionsv1alpha1.ExtensionClassShoot
+	}
+
+	extensions := &extensionsv1alpha1.ExtensionList{}
+	if err := c.List(ctx, extensions); err != nil {
+		return fmt.Errorf("unable to list extensions: %w", err)
+	}
+
+	for _, ex := range extensions.Items {
+		if ex.Spec.Type != Type || extensionsv1alpha1helper.GetExtensionClassOrDefault(ex.Spec.Class) != class {
+			continue
+		}
+
+		patch := client.MergeFrom(ex.DeepCopy())
+		metav1.SetMetaDataAnnotation(&ex.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+		if err := c.Patch(ctx, &ex, patch); err != nil {
+			return fmt.Errorf("unable to request reconciliation of extension %s: %w", client.ObjectKeyFromObject(&ex), err)
+		}
+	}
+
+	return nil
+}
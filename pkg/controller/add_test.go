@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestResyncPeriod(t *testing.T) {
+	networkPolicy := config.NetworkPolicy{Enabled: true, SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute}}
+
+	tests := []struct {
+		name string
+		cc   config.ControllerConfiguration
+		want time.Duration
+	}{
+		{
+			name: "no periodic reconciliation",
+		},
+		{
+			name: "client certificates are renewed",
+			cc:   config.ControllerConfiguration{Accounting: config.Accounting{ClientCA: &config.ClientCA{}}},
+			want: clientCertificateResync,
+		},
+		{
+			name: "accounting-api host is resolved again for the network policies",
+			cc: config.ControllerConfiguration{
+				Accounting:    config.Accounting{ClientCA: &config.ClientCA{}},
+				NetworkPolicy: networkPolicy,
+			},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "shorter interval of the client certificates takes precedence",
+			cc: config.ControllerConfiguration{
+				Accounting:    config.Accounting{ClientCA: &config.ClientCA{}},
+				NetworkPolicy: config.NetworkPolicy{Enabled: true, SyncPeriod: &metav1.Duration{Duration: 48 * time.Hour}},
+			},
+			want: clientCertificateResync,
+		},
+		{
+			name: "configured cidrs of the network policies are not resolved",
+			cc: config.ControllerConfiguration{
+				NetworkPolicy: config.NetworkPolicy{Enabled: true, CIDRs: []string{"10.0.0.0/24"}, SyncPeriod: networkPolicy.SyncPeriod},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resyncPeriod(&tt.cc); got != tt.want {
+				t.Errorf("resyncPeriod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileAll(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to create scheme: %s", err)
+	}
+
+	extension := func(name, extensionType string, class *extensionsv1alpha1.ExtensionClass) client.Object {
+		return &extensionsv1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec: extensionsv1alpha1.ExtensionSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: extensionType, Class: class},
+			},
+		}
+	}
+
+	objects := []client.Object{
+		extension("without-class", Type, nil),
+		extension("shoot", Type, pointer.Pointer(extensionsv1alpha1.ExtensionClassShoot)),
+		extension("garden", Type, pointer.Pointer(extensionsv1alpha1.ExtensionClassGarden)),
+		extension("other-type", "other", nil),
+	}
+
+	tests := []struct {
+		name  string
+		class extensionsv1alpha1.ExtensionClass
+		want  []string
+	}{
+		{
+			name: "no class defaults to shoot extensions",
+			want: []string{"shoot", "without-class"},
+		},
+		{
+			name:  "shoot class",
+			class: extensionsv1alpha1.ExtensionClassShoot,
+			want:  []string{"shoot", "without-class"},
+		},
+		{
+			name:  "garden class",
+			class: extensionsv1alpha1.ExtensionClassGarden,
+			want:  []string{"garden"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			if err := reconcileAll(ctx, c, tt.class); err != nil {
+				t.Fatalf("reconcileAll() error = %s", err)
+			}
+
+			extensions := &extensionsv1alpha1.ExtensionList{}
+			if err := c.List(ctx, extensions); err != nil {
+				t.Fatalf("unable to list extensions: %s", err)
+			}
+
+			var got []string
+			for _, ex := range extensions.Items {
+				if ex.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile {
+					got = append(got, ex.Name)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("reconciled extensions diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
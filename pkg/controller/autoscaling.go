@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+// exporterResources returns the resource requirements of the accounting-exporter,
+// the resources configured for the shoot override the defaults of the controller per resource name.
+// They are capped to the maximum resources, as the admission does not validate shoots created before the maximum was lowered.
+func exporterResources(cc *config.ControllerConfiguration, accountingConfig *v1alpha1.AccountingConfig) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	for _, r := range []*corev1.ResourceRequirements{cc.Accounting.ExporterResources, accountingConfig.ExporterResources} {
+		if r == nil {
+			continue
+		}
+		for name, quantity := range r.Requests {
+			resources.Requests[name] = quantity
+		}
+		for name, quantity := range r.Limits {
+			resources.Limits[name] = quantity
+		}
+	}
+
+	for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+		for name, quantity := range list {
+			if maximum, ok := cc.Accounting.ExporterMaxResources[name]; ok && quantity.Cmp(maximum) > 0 {
+				list[name] = maximum
+			}
+		}
+	}
+
+	// an overridden request must not exceed the default limit
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			resources.Limits[name] = request
+		}
+	}
+
+	return resources
+}
+
+// accountingExporterVPA scales the requests of the accounting-exporter, whose memory usage grows with the amount
+// of pods and persistent volume claims in the shoot. The configured requests are the lower bound of the recommendations,
+// the upper bound is capped to the maximum resources. Only the requests are scaled, the limits stay as configured.
+func accountingExporterVPA(cc *config.ControllerConfiguration, namespace string, resources corev1.ResourceRequirements) *vpaautoscalingv1.VerticalPodAutoscaler {
+	maxAllowed := corev1.ResourceList{}
+	for name, quantity := range cc.Accounting.ExporterVPA.MaxAllowed {
+		maxAllowed[name] = quantity
+	}
+	for name, maximum := range cc.Accounting.ExporterMaxResources {
+		if quantity, ok := maxAllowed[name]; !ok || quantity.Cmp(maximum) > 0 {
+			maxAllowed[name] = maximum
+		}
+	}
+
+	return &vpaautoscalingv1.VerticalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.AccountingExporterName,
+			Namespace: namespace,
+		},
+		Spec: vpaautoscalingv1.VerticalPodAutoscalerSpec{
+			TargetRef: &autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       v1alpha1.AccountingExporterName,
+			},
+			UpdatePolicy: &vpaautoscalingv1.PodUpdatePolicy{
+				UpdateMode: pointer.Pointer(vpaautoscalingv1.UpdateModeAuto),
+			},
+			ResourcePolicy: &vpaautoscalingv1.PodResourcePolicy{
+				ContainerPolicies: []vpaautoscalingv1.ContainerResourcePolicy{
+					{
+						ContainerName:       v1alpha1.AccountingExporterName,
+						MinAllowed:          resources.Requests,
+						MaxAllowed:          maxAllowed,
+						ControlledResources: &[]corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory},
+						ControlledValues:    pointer.Pointer(vpaautoscalingv1.ContainerControlledValuesRequestsOnly),
+					},
+				},
+			},
+		},
+	}
+}
+
+// accountingExporterPodDisruptionBudget keeps the single accounting-exporter from being evicted voluntarily, as every eviction
+// is a gap in the accounting. Node drains wait for the accounting-exporter until the drain timeout forces its eviction.
+// Unhealthy pods can always be evicted, so that a crash-looping accounting-exporter does not block the drains.
+func accountingExporterPodDisruptionBudget(namespace string) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.AccountingExporterName,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: pointer.Pointer(intstr.FromInt32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"k8s-app": "accounting-exporter",
+				},
+			},
+			UnhealthyPodEvictionPolicy: pointer.Pointer(policyv1.AlwaysAllow),
+		},
+	}
+}
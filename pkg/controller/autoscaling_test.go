@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestExporterResources(t *testing.T) {
+	cc := &config.ControllerConfiguration{
+		Accounting: config.Accounting{
+			ExporterResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+			ExporterMaxResources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overrides *corev1.ResourceRequirements
+		want      corev1.ResourceRequirements
+	}{
+		{
+			name: "defaults of the controller",
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+		{
+			name: "overrides of the shoot per resource name",
+			overrides: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+		{
+			name: "limit is raised to an overridden request",
+			overrides: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			name: "overrides are capped to the maximum",
+			overrides: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exporterResources(cc, &v1alpha1.AccountingConfig{ExporterResources: tt.overrides})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("exporterResources() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAccountingExporterVPA(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxAllowed   corev1.ResourceList
+		maxResources corev1.ResourceList
+		want         corev1.ResourceList
+	}{
+		{
+			name:       "upper bound of the vpa",
+			maxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			want:       corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		{
+			name:         "upper bound is capped to the maximum resources",
+			maxAllowed:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			maxResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			want:         corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		{
+			name:         "maximum resources bound the resources without an upper bound of the vpa",
+			maxResources: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			want:         corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &config.ControllerConfiguration{
+				Accounting: config.Accounting{
+					ExporterVPA:          config.ExporterVPA{MaxAllowed: tt.maxAllowed},
+					ExporterMaxResources: tt.maxResources,
+				},
+			}
+
+			vpa := accountingExporterVPA(cc, "shoot--test--test", corev1.ResourceRequirements{})
+			policy := vpa.Spec.ResourcePolicy.ContainerPolicies[0]
+
+			if diff := cmp.Diff(tt.want, policy.MaxAllowed); diff != "" {
+				t.Errorf("max allowed diff (-want +got):\n%s", diff)
+			}
+			if got := policy.ControlledValues; got == nil || *got != vpaautoscalingv1.ContainerControlledValuesRequestsOnly {
+				t.Errorf("controlled values = %v, want only the requests", got)
+			}
+		})
+	}
+}
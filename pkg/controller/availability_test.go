@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/identity"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/resolver"
+)
+
+// testSeedObjects returns the seed objects of a shoot with the given configuration and accounting status.
+func testSeedObjects(t *testing.T, cc *config.ControllerConfiguration, shoot *gardencorev1beta1.Shoot, status *v1alpha1.AccountingStatus) []client.Object {
+	t.Helper()
+
+	cc.Accounting.AccountingHost = "accounting.example.com"
+	cc.Accounting.AccountingPort = "9000"
+	cc.Accounting.ExporterPort = 3000
+
+	shoot.Name = "test"
+	shoot.Namespace = "garden-test"
+
+	objects, err := seedObjects(cc, &v1alpha1.AccountingConfig{}, &identity.Identity{PartitionID: "partition-a", ProjectID: "p1"},
+		&resolver.Project{ID: "p1", Name: "project", TenantID: "tenant"}, "accounting-exporter:latest",
+		&controller.Cluster{Shoot: shoot}, status, testNamespace, "shoot-access-accounting-exporter", nil, nil)
+	if err != nil {
+		t.Fatalf("seedObjects() error = %s", err)
+	}
+
+	return objects
+}
+
+func TestSeedObjectsAvailability(t *testing.T) {
+	haShoot := func() *gardencorev1beta1.Shoot {
+		return &gardencorev1beta1.Shoot{
+			Spec: gardencorev1beta1.ShootSpec{
+				ControlPlane: &gardencorev1beta1.ControlPlane{
+					HighAvailability: &gardencorev1beta1.HighAvailability{
+						FailureTolerance: gardencorev1beta1.FailureTolerance{Type: gardencorev1beta1.FailureToleranceTypeZone},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		availability config.ExporterAvailability
+		shoot        *gardencorev1beta1.Shoot
+		wantPDB      bool
+		wantHA       bool
+	}{
+		{
+			name:         "priority class and pod disruption budget",
+			availability: config.ExporterAvailability{PriorityClassName: "gardener-system-200", PodDisruptionBudget: pointer.Pointer(true), HighAvailability: pointer.Pointer(true)},
+			shoot:        &gardencorev1beta1.Shoot{},
+			wantPDB:      true,
+		},
+		{
+			name:         "high availability settings for a highly available control plane",
+			availability: config.ExporterAvailability{PriorityClassName: "gardener-system-200", PodDisruptionBudget: pointer.Pointer(true), HighAvailability: pointer.Pointer(true)},
+			shoot:        haShoot(),
+			wantPDB:      true,
+			wantHA:       true,
+		},
+		{
+			name:         "disabled pod disruption budget and high availability settings",
+			availability: config.ExporterAvailability{PriorityClassName: "gardener-system-200", PodDisruptionBudget: pointer.Pointer(false), HighAvailability: pointer.Pointer(false)},
+			shoot:        haShoot(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &config.ControllerConfiguration{Accounting: config.Accounting{ExporterAvailability: tt.availability}}
+
+			var (
+				deployment *appsv1.Deployment
+				pdb        *policyv1.PodDisruptionBudget
+			)
+			for _, obj := range testSeedObjects(t, cc, tt.shoot, &v1alpha1.AccountingStatus{}) {
+				switch o := obj.(type) {
+				case *appsv1.Deployment:
+					deployment = o
+				case *policyv1.PodDisruptionBudget:
+					pdb = o
+				}
+			}
+
+			if got := deployment.Spec.Template.Spec.PriorityClassName; got != tt.availability.PriorityClassName {
+				t.Errorf("priority class = %q, want %q", got, tt.availability.PriorityClassName)
+			}
+
+			if (pdb != nil) != tt.wantPDB {
+				t.Fatalf("pod disruption budget deployed = %t, want %t", pdb != nil, tt.wantPDB)
+			}
+			if pdb != nil {
+				if got := pdb.Spec.MinAvailable; got == nil || *got != intstr.FromInt32(1) {
+					t.Errorf("pod disruption budget min available = %v, want 1", got)
+				}
+				if pdb.Spec.MaxUnavailable != nil {
+					t.Errorf("pod disruption budget max unavailable = %v, want unset", pdb.Spec.MaxUnavailable)
+				}
+				if got := pointer.SafeDeref(pdb.Spec.UnhealthyPodEvictionPolicy); got != policyv1.AlwaysAllow {
+					t.Errorf("pod disruption budget unhealthy pod eviction policy = %q, want %q", got, policyv1.AlwaysAllow)
+				}
+				selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+				if err != nil {
+					t.Fatalf("invalid pod disruption budget selector: %s", err)
+				}
+				if !selector.Matches(labels.Set(deployment.Spec.Template.Labels)) {
+					t.Errorf("pod disruption budget does not select the accounting-exporter")
+				}
+			}
+
+			_, ha := deployment.Labels[resourcesv1alpha1.HighAvailabilityConfigType]
+			if ha != tt.wantHA {
+				t.Errorf("high availability settings = %t, want %t", ha, tt.wantHA)
+			}
+			if ha && deployment.Annotations[resourcesv1alpha1.HighAvailabilityConfigReplicas] != "1" {
+				t.Errorf("accounting-exporter must keep running with a single replica, annotations: %v", deployment.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package cwnp
+
+import (
+	"context"
+	"fmt"
+
+	firewallv2 "github.com/metal-stack/firewall-controller/v2/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+const (
+	// ControllerName is the name of the controller deploying the clusterwide network policy for the accounting-api.
+	ControllerName = "fits-accounting-cwnp"
+	// PolicyName is the name of the clusterwide network policy allowing the egress traffic to the accounting-api.
+	PolicyName = "egress-allow-accounting-api"
+)
+
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the clusterwide network policy controller to the manager.
+type AddOptions struct {
+	// ControllerOptions contains options for the controller.
+	ControllerOptions controller.Options
+	// Config contains the configuration of the extension.
+	Config config.ControllerConfiguration
+}
+
+// AddToManager adds a controller with the default Options to the given Controller Manager.
+func AddToManager(ctx context.Context, mgr manager.Manager) error {
+	return AddToManagerWithOptions(ctx, mgr, DefaultAddOptions)
+}
+
+// AddToManagerWithOptions adds a controller with the given Options to the given manager.
+// The controller is not added if the seed does not serve clusterwide network policies, i.e. there is no firewall-controller.
+func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
+	log := logf.FromContext(ctx).WithName(ControllerName)
+
+	if err := firewallv2.AddToScheme(mgr.GetScheme()); err != nil {
+		return fmt.Errorf("could not update manager scheme: %w", err)
+	}
+
+	if _, err := mgr.GetRESTMapper().RESTMapping(firewallv2.GroupVersion.WithKind("ClusterwideNetworkPolicy").GroupKind(), firewallv2.GroupVersion.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			log.Info("clusterwide network policies are not served by the seed, not adding controller")
+			return nil
+		}
+		return fmt.Errorf("unable to look up clusterwide network policy resource: %w", err)
+	}
+
+	key := types.NamespacedName{Namespace: opts.Config.ClusterwideNetworkPolicy.Namespace, Name: PolicyName}
+
+	return builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(opts.ControllerOptions).
+		For(&firewallv2.ClusterwideNetworkPolicy{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return client.ObjectKeyFromObject(obj) == key
+		}))).
+		// the policy does not exist initially, so it is not created by a watch event
+		WatchesRawSource(source.Func(func(_ context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			queue.Add(reconcile.Request{NamespacedName: key})
+			return nil
+		})).
+		Complete(&reconciler{
+			client: mgr.GetClient(),
+			config: opts.Config,
+		})
+}
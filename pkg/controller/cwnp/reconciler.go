@@ -0,0 +1,111 @@
+package cwnp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	firewallv2 "github.com/metal-stack/firewall-controller/v2/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/endpoint"
+)
+
+type reconciler struct {
+	client client.Client
+	config config.ControllerConfiguration
+}
+
+// Reconcile deploys the clusterwide network policy allowing the egress traffic to the accounting-apis of all partitions.
+// It is requeued after the sync period to pick up changed addresses of the accounting-api hosts.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// if a host cannot be resolved, the existing policy is left untouched
+	cidrsByPort, err := r.cidrsByPort(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cwnp := &firewallv2.ClusterwideNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.client, cwnp, func() error {
+		tcp := corev1.ProtocolTCP
+
+		cwnp.Spec.Egress = nil
+		for _, port := range slices.Sorted(maps.Keys(cidrsByPort)) {
+			egressPort := intstr.FromInt(port)
+
+			var to []networkingv1.IPBlock
+			for _, cidr := range cidrsByPort[port] {
+				to = append(to, networkingv1.IPBlock{CIDR: cidr})
+			}
+
+			cwnp.Spec.Egress = append(cwnp.Spec.Egress, firewallv2.EgressRule{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Port:     &egressPort,
+						Protocol: &tcp,
+					},
+				},
+				To: to,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to deploy clusterwide network policy for accounting-api: %w", err)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		log.Info("deployed clusterwide network policy for accounting-api", "operation", result, "destinations", cidrsByPort)
+	}
+
+	return reconcile.Result{RequeueAfter: r.config.ClusterwideNetworkPolicy.SyncPeriod.Duration}, nil
+}
+
+// cidrsByPort returns the destinations of the accounting-apis by their port. The configured destinations are allowed
+// on the ports of all accounting-apis, otherwise the addresses of the accounting-api hosts are resolved.
+func (r *reconciler) cidrsByPort(ctx context.Context) (map[int][]string, error) {
+	cidrsByPort := map[int][]string{}
+
+	for _, accountingAPI := range endpoint.All(&r.config.Accounting) {
+		port, err := strconv.Atoi(accountingAPI.AccountingPort)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse port of accounting-api %q: %w", accountingAPI.AccountingHost, err)
+		}
+
+		cidrs := r.config.ClusterwideNetworkPolicy.CIDRs
+		if len(cidrs) == 0 {
+			cidrs, err = endpoint.ResolveCIDRs(ctx, accountingAPI.AccountingHost)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve accounting-api host: %w", err)
+			}
+		}
+
+		cidrsByPort[port] = append(cidrsByPort[port], cidrs...)
+	}
+
+	for port, cidrs := range cidrsByPort {
+		slices.Sort(cidrs)
+		cidrsByPort[port] = slices.Compact(cidrs)
+	}
+
+	return cidrsByPort, nil
+}
@@ -0,0 +1,137 @@
+package cwnp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	firewallv2 "github.com/metal-stack/firewall-controller/v2/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := firewallv2.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to create scheme: %s", err)
+	}
+
+	key := types.NamespacedName{Namespace: "firewall", Name: PolicyName}
+
+	egress := func(port int, cidrs ...string) firewallv2.EgressRule {
+		tcp := corev1.ProtocolTCP
+		p := intstr.FromInt(port)
+
+		rule := firewallv2.EgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{Port: &p, Protocol: &tcp}},
+		}
+		for _, cidr := range cidrs {
+			rule.To = append(rule.To, networkingv1.IPBlock{CIDR: cidr})
+		}
+		return rule
+	}
+
+	tests := []struct {
+		name       string
+		accounting config.Accounting
+		cidrs      []string
+		existing   []client.Object
+		want       []firewallv2.EgressRule
+		wantErr    bool
+	}{
+		{
+			name:       "address of the accounting-api",
+			accounting: config.Accounting{AccountingHost: "10.0.0.1", AccountingPort: "9000"},
+			want:       []firewallv2.EgressRule{egress(9000, "10.0.0.1/32")},
+		},
+		{
+			name: "accounting-apis of the partitions are grouped by port",
+			accounting: config.Accounting{
+				AccountingHost: "10.0.0.1",
+				AccountingPort: "9000",
+				Partitions: map[string]config.AccountingEndpoint{
+					"partition-b": {AccountingHost: "2001:db8::1"},
+					"partition-c": {AccountingHost: "10.0.0.3", AccountingPort: "9443"},
+					"partition-d": {AccountingHost: "10.0.0.1"},
+				},
+			},
+			want: []firewallv2.EgressRule{
+				egress(9000, "10.0.0.1/32", "2001:db8::1/128"),
+				egress(9443, "10.0.0.3/32"),
+			},
+		},
+		{
+			name:       "configured cidrs take precedence",
+			accounting: config.Accounting{AccountingHost: "10.0.0.1", AccountingPort: "9000"},
+			cidrs:      []string{"10.0.0.0/24"},
+			want:       []firewallv2.EgressRule{egress(9000, "10.0.0.0/24")},
+		},
+		{
+			name:       "drifted policy is restored",
+			accounting: config.Accounting{AccountingHost: "10.0.0.1", AccountingPort: "9000"},
+			existing: []client.Object{
+				&firewallv2.ClusterwideNetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: firewallv2.PolicySpec{
+						Egress: []firewallv2.EgressRule{egress(443, "0.0.0.0/0")},
+					},
+				},
+			},
+			want: []firewallv2.EgressRule{egress(9000, "10.0.0.1/32")},
+		},
+		{
+			name:       "invalid port",
+			accounting: config.Accounting{AccountingHost: "10.0.0.1", AccountingPort: "http"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.existing...).Build()
+
+			r := &reconciler{
+				client: c,
+				config: config.ControllerConfiguration{
+					Accounting: tt.accounting,
+					ClusterwideNetworkPolicy: config.ClusterwideNetworkPolicy{
+						Namespace:  key.Namespace,
+						CIDRs:      tt.cidrs,
+						SyncPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.RequeueAfter != 5*time.Minute {
+				t.Errorf("Reconcile() requeue after = %s, want the sync period", result.RequeueAfter)
+			}
+
+			cwnp := &firewallv2.ClusterwideNetworkPolicy{}
+			if err := c.Get(ctx, key, cwnp); err != nil {
+				t.Fatalf("unable to get clusterwide network policy: %s", err)
+			}
+			if diff := cmp.Diff(tt.want, cwnp.Spec.Egress); diff != "" {
+				t.Errorf("egress rules diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
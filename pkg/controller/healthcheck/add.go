@@ -0,0 +1,70 @@
+package healthcheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/controller"
+
+	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck/general"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var (
+	defaultSyncPeriod = time.Second * 30
+	// DefaultAddOptions are the default DefaultAddArgs for AddToManager.
+	DefaultAddOptions = healthcheck.DefaultAddArgs{
+		HealthCheckConfig: healthcheckconfig.HealthCheckConfig{
+			SyncPeriod: metav1.Duration{Duration: defaultSyncPeriod},
+		},
+	}
+)
+
+// RegisterHealthChecks registers health checks for the accounting extension resource.
+// The seed side (managed resource and exporter deployment) contributes to the ControlPlaneHealthy condition,
+// the shoot side (managed resource with the rbac for the exporter) contributes to the SystemComponentsHealthy condition.
+func RegisterHealthChecks(_ context.Context, mgr manager.Manager, opts healthcheck.DefaultAddArgs) error {
+	return healthcheck.DefaultRegistration(
+		controller.Type,
+		extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.ExtensionResource),
+		func() client.ObjectList { return &extensionsv1alpha1.ExtensionList{} },
+		func() extensionsv1alpha1.Object { return &extensionsv1alpha1.Extension{} },
+		mgr,
+		opts,
+		nil,
+		healthChecks(),
+		sets.New[gardencorev1beta1.ConditionType](),
+	)
+}
+
+// healthChecks returns the health checks of the accounting extension resource mapped to the conditions they contribute to.
+func healthChecks() []healthcheck.ConditionTypeToHealthCheck {
+	return []healthcheck.ConditionTypeToHealthCheck{
+		{
+			ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+			HealthCheck:   general.CheckManagedResource(v1alpha1.SeedAccountingResourceName),
+		},
+		{
+			ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+			HealthCheck:   general.NewSeedDeploymentHealthChecker(v1alpha1.AccountingExporterName),
+		},
+		{
+			ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+			HealthCheck:   general.CheckManagedResource(v1alpha1.ShootAccountingResourceName),
+		},
+	}
+}
+
+// AddToManager adds a controller with the default Options.
+func AddToManager(ctx context.Context, mgr manager.Manager) error {
+	return RegisterHealthChecks(ctx, mgr, DefaultAddOptions)
+}
@@ -0,0 +1,59 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHealthChecks(t *testing.T) {
+	type check struct {
+		ConditionType string
+		Detail        string
+	}
+
+	// the checks report the object they look for when it does not exist, which tells which object is checked for which condition
+	want := []check{
+		{
+			ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+			Detail:        `Managed Resource "extension-fits-accounting" in namespace "shoot--test--test" not found`,
+		},
+		{
+			ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+			Detail:        `deployment "accounting-exporter" in namespace "shoot--test--test" not found`,
+		},
+		{
+			ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+			Detail:        `Managed Resource "extension-fits-accounting-shoot" in namespace "shoot--test--test" not found`,
+		},
+	}
+
+	var got []check
+	for _, c := range healthChecks() {
+		seedClient, ok := c.HealthCheck.(healthcheck.SeedClient)
+		if !ok {
+			t.Fatalf("health check %T of condition %s is not executed in the seed", c.HealthCheck, c.ConditionType)
+		}
+		seedClient.InjectSeedClient(fake.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build())
+
+		result, err := c.HealthCheck.Check(context.Background(), types.NamespacedName{Namespace: "shoot--test--test", Name: "accounting"})
+		if err != nil {
+			t.Fatalf("Check() error = %s", err)
+		}
+		if result.Status != gardencorev1beta1.ConditionFalse {
+			t.Errorf("Check() status = %s, want %s", result.Status, gardencorev1beta1.ConditionFalse)
+		}
+
+		got = append(got, check{ConditionType: c.ConditionType, Detail: result.Detail})
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("healthChecks() diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	"github.com/gardener/gardener/pkg/utils/managedresources"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/metrics"
+)
+
+const (
+	// EventReasonClusterHibernated is the reason of the event recorded when the cluster was hibernated, before the
+	// accounting-exporter is scaled down.
+	EventReasonClusterHibernated = "ClusterHibernated"
+	// EventReasonClusterWokeUp is the reason of the event recorded when the cluster woke up, once the accounting-exporter
+	// was scaled up and is available.
+	EventReasonClusterWokeUp = "ClusterWokeUp"
+
+	// exporterAvailableTimeout is the time the scaled up accounting-exporter has to become available after a wake up.
+	exporterAvailableTimeout = 2 * time.Minute
+)
+
+// hibernationTransition returns whether the hibernation state of the cluster differs from the one the accounting-exporter
+// was deployed for. The first reconciliation of a cluster is no transition.
+func hibernationTransition(status *v1alpha1.AccountingStatus, hibernated bool) bool {
+	if status.LastSuccessfulReconcileTime == nil {
+		return false
+	}
+	return status.Hibernated != hibernated
+}
+
+// startHibernationTransition records the time of the hibernation transition of the cluster. A hibernation is recorded right
+// away, as the accounting-exporter is scaled down with this reconciliation. A wake up is pending until the scaled up
+// accounting-exporter is available. The status is stored on failures, so the transition keeps its time while it is pending.
+func (a *actuator) startHibernationTransition(ex *extensionsv1alpha1.Extension, status *v1alpha1.AccountingStatus, hibernated bool) {
+	status.LastHibernationTransitionTime = pointer.Pointer(metav1.NewTime(a.clock.Now()))
+	status.HibernationTransitionPending = !hibernated
+
+	if hibernated {
+		a.recordHibernationTransition(ex, hibernated)
+	}
+}
+
+// completeWakeUp waits until the scaled up accounting-exporter of a woken up cluster is available and records the wake up afterwards.
+func (a *actuator) completeWakeUp(ctx context.Context, ex *extensionsv1alpha1.Extension, status *v1alpha1.AccountingStatus) error {
+	namespace := ex.GetNamespace()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, exporterAvailableTimeout)
+	defer cancel()
+
+	// the managed resource is only healthy and not progressing once the scaled up accounting-exporter was rolled out
+	if err := managedresources.WaitUntilHealthyAndNotProgressing(timeoutCtx, a.client, namespace, v1alpha1.SeedAccountingResourceName); err != nil {
+		return fmt.Errorf("accounting-exporter did not become available after the wake up: %w", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: v1alpha1.AccountingExporterName}, deployment); err != nil {
+		return fmt.Errorf("unable to get accounting-exporter: %w", err)
+	}
+	if err := health.CheckDeployment(deployment); err != nil {
+		return fmt.Errorf("accounting-exporter did not become available after the wake up: %w", err)
+	}
+
+	a.recordHibernationTransition(ex, false)
+	status.HibernationTransitionPending = false
+
+	return nil
+}
+
+// recordHibernationTransition records an event on the extension and counts the transition. The transitions are not sent
+// to the accounting-api, the extension has no client of it and the accounting-exporter does not report them.
+func (a *actuator) recordHibernationTransition(ex *extensionsv1alpha1.Extension, hibernated bool) {
+	reason, transition, message := EventReasonClusterWokeUp, "woke_up", "cluster woke up, the accounting-exporter was scaled up and is available"
+	if hibernated {
+		reason, transition, message = EventReasonClusterHibernated, "hibernated", "cluster was hibernated, the accounting-exporter is scaled down"
+	}
+
+	a.recorder.Event(ex, corev1.EventTypeNormal, reason, message)
+	metrics.HibernationTransitions.WithLabelValues(transition).Inc()
+}
+
+// hibernatedExporters counts the extensions of the given class whose accounting-exporter is scaled down because of hibernation.
+// The count is taken from the provider status of the extensions, so it does not depend on the reconciliations since the start.
+func hibernatedExporters(ctx context.Context, c client.Reader, decoder runtime.Decoder, class extensionsv1alpha1.ExtensionClass) (int, error) {
+	// the controller is responsible for shoot extensions if no class is configured, see predicate.HasClass
+	if class == "" {
+		class = extensionsv1alpha1.ExtensionClassShoot
+	}
+
+	extensions := &extensionsv1alpha1.ExtensionList{}
+	if err := c.List(ctx, extensions); err != nil {
+		return 0, fmt.Errorf("unable to list extensions: %w", err)
+	}
+
+	count := 0
+	for _, ex := range extensions.Items {
+		if ex.Spec.Type != Type || extensionsv1alpha1helper.GetExtensionClassOrDefault(ex.Spec.Class) != class {
+			continue
+		}
+		if ex.DeletionTimestamp != nil || ex.Status.ProviderStatus == nil {
+			continue
+		}
+
+		status := &v1alpha1.AccountingStatus{}
+		if _, _, err := decoder.Decode(ex.Status.ProviderStatus.Raw, nil, status); err != nil {
+			// the status is overwritten on the next reconciliation
+			continue
+		}
+
+		if status.Hibernated {
+			count++
+		}
+	}
+
+	return count, nil
+}
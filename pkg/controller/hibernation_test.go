@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestHibernationTransition(t *testing.T) {
+	reconciled := pointer.Pointer(metav1.Now())
+
+	tests := []struct {
+		name       string
+		status     *v1alpha1.AccountingStatus
+		hibernated bool
+		want       bool
+	}{
+		{
+			name:       "first reconciliation of a hibernated cluster",
+			status:     &v1alpha1.AccountingStatus{},
+			hibernated: true,
+		},
+		{
+			name:       "cluster was hibernated",
+			status:     &v1alpha1.AccountingStatus{LastSuccessfulReconcileTime: reconciled},
+			hibernated: true,
+			want:       true,
+		},
+		{
+			name:   "cluster woke up",
+			status: &v1alpha1.AccountingStatus{LastSuccessfulReconcileTime: reconciled, Hibernated: true},
+			want:   true,
+		},
+		{
+			name:       "cluster stays hibernated",
+			status:     &v1alpha1.AccountingStatus{LastSuccessfulReconcileTime: reconciled, Hibernated: true},
+			hibernated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hibernationTransition(tt.status, tt.hibernated); got != tt.want {
+				t.Errorf("hibernationTransition() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeedObjectsHibernation(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       *v1alpha1.AccountingStatus
+		wantReplicas int32
+	}{
+		{
+			name:         "running cluster",
+			status:       &v1alpha1.AccountingStatus{},
+			wantReplicas: 1,
+		},
+		{
+			name:         "exporter is scaled down while the cluster is hibernated",
+			status:       &v1alpha1.AccountingStatus{Hibernated: true},
+			wantReplicas: 0,
+		},
+		{
+			name:         "exporter is scaled up when the cluster wakes up",
+			status:       &v1alpha1.AccountingStatus{HibernationTransitionPending: true},
+			wantReplicas: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deployment *appsv1.Deployment
+			for _, obj := range testSeedObjects(t, &config.ControllerConfiguration{}, &gardencorev1beta1.Shoot{}, tt.status) {
+				if d, ok := obj.(*appsv1.Deployment); ok {
+					deployment = d
+				}
+			}
+
+			if got := *deployment.Spec.Replicas; got != tt.wantReplicas {
+				t.Errorf("replicas = %d, want %d", got, tt.wantReplicas)
+			}
+		})
+	}
+}
+
+func TestStartHibernationTransition(t *testing.T) {
+	ex := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{Name: "accounting", Namespace: testNamespace},
+	}
+
+	tests := []struct {
+		name        string
+		hibernated  bool
+		wantPending bool
+		wantEvent   string
+	}{
+		{
+			name:       "hibernation is recorded before the exporter is scaled down",
+			hibernated: true,
+			wantEvent:  "Normal ClusterHibernated cluster was hibernated, the accounting-exporter is scaled down",
+		},
+		{
+			name:        "wake up is pending until the exporter is available",
+			wantPending: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestActuator(t)
+			recorder := record.NewFakeRecorder(1)
+			a.recorder = recorder
+
+			status := &v1alpha1.AccountingStatus{HibernationTransitionPending: true}
+
+			a.startHibernationTransition(ex, status, tt.hibernated)
+
+			if status.LastHibernationTransitionTime == nil || !status.LastHibernationTransitionTime.Time.Equal(a.clock.Now()) {
+				t.Errorf("last hibernation transition time = %v, want %v", status.LastHibernationTransitionTime, a.clock.Now())
+			}
+			if status.HibernationTransitionPending != tt.wantPending {
+				t.Errorf("hibernation transition pending = %t, want %t", status.HibernationTransitionPending, tt.wantPending)
+			}
+
+			var event string
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if event != tt.wantEvent {
+				t.Errorf("event = %q, want %q", event, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestCompleteWakeUp(t *testing.T) {
+	ex := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{Name: "accounting", Namespace: testNamespace},
+	}
+
+	managedResource := func(progressing gardencorev1beta1.ConditionStatus) *resourcesv1alpha1.ManagedResource {
+		return &resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.SeedAccountingResourceName, Namespace: testNamespace},
+			Status: resourcesv1alpha1.ManagedResourceStatus{
+				Conditions: []gardencorev1beta1.Condition{
+					{Type: resourcesv1alpha1.ResourcesApplied, Status: gardencorev1beta1.ConditionTrue},
+					{Type: resourcesv1alpha1.ResourcesHealthy, Status: gardencorev1beta1.ConditionTrue},
+					{Type: resourcesv1alpha1.ResourcesProgressing, Status: progressing},
+				},
+			},
+		}
+	}
+	deployment := func(available corev1.ConditionStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.AccountingExporterName, Namespace: testNamespace},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: available},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		managedResource *resourcesv1alpha1.ManagedResource
+		deployment      *appsv1.Deployment
+		wantErr         bool
+		wantEvent       string
+	}{
+		{
+			name:            "wake up is recorded once the exporter is available",
+			managedResource: managedResource(gardencorev1beta1.ConditionFalse),
+			deployment:      deployment(corev1.ConditionTrue),
+			wantEvent:       "Normal ClusterWokeUp cluster woke up, the accounting-exporter was scaled up and is available",
+		},
+		{
+			name:            "wake up is pending while the exporter is rolled out",
+			managedResource: managedResource(gardencorev1beta1.ConditionTrue),
+			deployment:      deployment(corev1.ConditionTrue),
+			wantErr:         true,
+		},
+		{
+			name:            "wake up is pending while the exporter is unavailable",
+			managedResource: managedResource(gardencorev1beta1.ConditionFalse),
+			deployment:      deployment(corev1.ConditionFalse),
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestActuator(t, tt.managedResource, tt.deployment)
+			recorder := record.NewFakeRecorder(1)
+			a.recorder = recorder
+
+			status := &v1alpha1.AccountingStatus{HibernationTransitionPending: true}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := a.completeWakeUp(ctx, ex, status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("completeWakeUp() error = %v, wantErr %t", err, tt.wantErr)
+			}
+
+			if status.HibernationTransitionPending != tt.wantErr {
+				t.Errorf("hibernation transition pending = %t, want %t", status.HibernationTransitionPending, tt.wantErr)
+			}
+
+			var event string
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if event != tt.wantEvent {
+				t.Errorf("event = %q, want %q", event, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestHibernatedExporters(t *testing.T) {
+	extension := func(name string, class *extensionsv1alpha1.ExtensionClass, providerStatus string) *extensionsv1alpha1.Extension {
+		ex := &extensionsv1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{Name: "accounting", Namespace: name},
+			Spec: extensionsv1alpha1.ExtensionSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: Type, Class: class},
+			},
+		}
+		if providerStatus != "" {
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(providerStatus)}
+		}
+		return ex
+	}
+
+	const (
+		hibernated = `{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingStatus","hibernated":true}`
+		running    = `{"apiVersion":"accounting.fits.extensions.gardener.cloud/v1alpha1","kind":"AccountingStatus"}`
+	)
+
+	other := extension("shoot--test--other", nil, hibernated)
+	other.Spec.Type = "other"
+
+	a := newTestActuator(t,
+		extension("shoot--test--hibernated", nil, hibernated),
+		extension("shoot--test--running", nil, running),
+		extension("shoot--test--new", nil, ""),
+		extension("shoot--test--invalid", nil, `{"apiVersion":"accounting.fits.extensions.gardener.cloud/v2","kind":"AccountingStatus","hibernated":true}`),
+		extension("shoot--test--seed", pointer.Pointer(extensionsv1alpha1.ExtensionClassSeed), hibernated),
+		other,
+	)
+
+	decoder := serializer.NewCodecFactory(a.client.Scheme()).UniversalDecoder()
+
+	got, err := hibernatedExporters(context.Background(), a.client, decoder, "")
+	if err != nil {
+		t.Fatalf("hibernatedExporters() error = %s", err)
+	}
+	if got != 1 {
+		t.Errorf("hibernatedExporters() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"fmt"
+
+	monitoringutils "github.com/gardener/gardener/pkg/component/observability/monitoring/utils"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+const (
+	accountingExporterMetricsPortName = "metrics"
+	// accountingExporterJob is the job of the accounting-exporter metrics, the prometheus-operator names it after the service
+	accountingExporterJob = v1alpha1.AccountingExporterName
+)
+
+// monitoringObjects returns the service of the accounting-exporter and the configuration for the prometheus of the shoot
+// to scrape it and to alert when the accounting-exporter does not send events to the accounting-api anymore.
+// The alerting rules are omitted while the shoot is hibernated, as the accounting-exporter is scaled down then.
+func monitoringObjects(cc *config.ControllerConfiguration, namespace string, hibernated bool) ([]client.Object, error) {
+	var (
+		tcp          = corev1.ProtocolTCP
+		exporterPort = intstr.FromInt32(cc.Accounting.ExporterPort)
+		threshold    = cc.Monitoring.NoEventsSentAlertThreshold.Duration
+	)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.AccountingExporterName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"k8s-app": "accounting-exporter",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				"k8s-app": "accounting-exporter",
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       accountingExporterMetricsPortName,
+					Port:       cc.Accounting.ExporterPort,
+					TargetPort: intstr.FromString("health"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	if err := gutil.InjectNetworkPolicyAnnotationsForScrapeTargets(service, networkingv1.NetworkPolicyPort{Port: &exporterPort, Protocol: &tcp}); err != nil {
+		return nil, fmt.Errorf("unable to inject network policy annotations into accounting-exporter service: %w", err)
+	}
+
+	serviceMonitor := &monitoringv1.ServiceMonitor{
+		ObjectMeta: monitoringutils.ConfigObjectMeta(v1alpha1.AccountingExporterName, namespace, shootPrometheusName),
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"k8s-app": "accounting-exporter",
+				},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port: accountingExporterMetricsPortName,
+				},
+			},
+		},
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{
+		ObjectMeta: monitoringutils.ConfigObjectMeta(v1alpha1.AccountingExporterName, namespace, shootPrometheusName),
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "accounting-exporter.rules",
+					Rules: []monitoringv1.Rule{
+						{
+							Alert:  "AccountingExporterDown",
+							Expr:   intstr.FromString(fmt.Sprintf(`absent(up{job=%q} == 1)`, accountingExporterJob)),
+							For:    pointer.Pointer(monitoringv1.Duration("15m")),
+							Labels: alertLabels("critical"),
+							Annotations: map[string]string{
+								"summary":     "Accounting-exporter is down",
+								"description": "The accounting-exporter of the shoot is not running, the usage of the shoot is not accounted.",
+							},
+						},
+						{
+							Alert:  "AccountingExporterNoEventsSent",
+							Expr:   intstr.FromString(fmt.Sprintf(`time() - max(%s{job=%q}) > %d`, cc.Monitoring.LastEventSentMetric, accountingExporterJob, int64(threshold.Seconds()))),
+							For:    pointer.Pointer(monitoringv1.Duration("5m")),
+							Labels: alertLabels("critical"),
+							Annotations: map[string]string{
+								"summary":     "Accounting-exporter does not send events",
+								"description": fmt.Sprintf("The accounting-exporter of the shoot has not sent events to the accounting-api for more than %s, the usage of the shoot is not accounted.", threshold),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if hibernated {
+		return []client.Object{service, serviceMonitor}, nil
+	}
+
+	return []client.Object{service, serviceMonitor, prometheusRule}, nil
+}
+
+func alertLabels(severity string) map[string]string {
+	return map[string]string{
+		"service":    v1alpha1.AccountingExporterName,
+		"severity":   severity,
+		"type":       "seed",
+		"visibility": "operator",
+	}
+}
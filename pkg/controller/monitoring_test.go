@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestMonitoringObjects(t *testing.T) {
+	cc := &config.ControllerConfiguration{
+		Accounting: config.Accounting{ExporterPort: 3000},
+		Monitoring: config.Monitoring{
+			LastEventSentMetric:        "last_event_sent",
+			NoEventsSentAlertThreshold: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		hibernated bool
+		wantAlerts []string
+	}{
+		{
+			name:       "running shoot",
+			wantAlerts: []string{"AccountingExporterDown", "AccountingExporterNoEventsSent"},
+		},
+		{
+			name:       "alerts are omitted while the shoot is hibernated",
+			hibernated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				services        int
+				serviceMonitors int
+				alerts          []string
+				expressions     []string
+			)
+
+			objects, err := monitoringObjects(cc, testNamespace, tt.hibernated)
+			if err != nil {
+				t.Fatalf("monitoringObjects() error = %s", err)
+			}
+
+			for _, obj := range objects {
+				if obj.GetNamespace() != testNamespace {
+					t.Errorf("%s is deployed to namespace %q, want %q", obj.GetName(), obj.GetNamespace(), testNamespace)
+				}
+
+				switch o := obj.(type) {
+				case *corev1.Service:
+					services++
+					if got := o.Spec.Ports[0].Port; got != 3000 {
+						t.Errorf("port = %d, want the exporter port", got)
+					}
+					scrapeTargets := resourcesv1alpha1.NetworkPolicyFromPolicyAnnotationPrefix + v1beta1constants.LabelNetworkPolicyScrapeTargets + resourcesv1alpha1.NetworkPolicyFromPolicyAnnotationSuffix
+					if _, ok := o.Annotations[scrapeTargets]; !ok {
+						t.Errorf("service is not reachable by prometheus, annotations: %v", o.Annotations)
+					}
+				case *monitoringv1.ServiceMonitor:
+					serviceMonitors++
+					if got := o.Labels["prometheus"]; got != shootPrometheusName {
+						t.Errorf("%s is selected by prometheus %q, want %q", o.Name, got, shootPrometheusName)
+					}
+				case *monitoringv1.PrometheusRule:
+					if got := o.Labels["prometheus"]; got != shootPrometheusName {
+						t.Errorf("%s is selected by prometheus %q, want %q", o.Name, got, shootPrometheusName)
+					}
+					for _, group := range o.Spec.Groups {
+						for _, rule := range group.Rules {
+							alerts = append(alerts, rule.Alert)
+							expressions = append(expressions, rule.Expr.String())
+						}
+					}
+				default:
+					t.Errorf("unexpected object %T", obj)
+				}
+			}
+
+			if services != 1 {
+				t.Errorf("services = %d, want 1", services)
+			}
+			if serviceMonitors != 1 {
+				t.Errorf("service monitors = %d, want 1", serviceMonitors)
+			}
+			if diff := cmp.Diff(tt.wantAlerts, alerts); diff != "" {
+				t.Errorf("alerts diff (-want +got):\n%s", diff)
+			}
+			if len(expressions) > 1 && expressions[1] != `time() - max(last_event_sent{job="accounting-exporter"}) > 3600` {
+				t.Errorf("no events sent expression = %s, want the configured metric and threshold", expressions[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/endpoint"
+)
+
+const (
+	kubeAPIServerPort = 443
+	// shootPrometheusName is the name label of the prometheus scraping the shoot control plane components
+	shootPrometheusName = "shoot"
+)
+
+// accountingAPICIDRs returns the destinations of the egress traffic to the accounting-api with the given host for the network policy.
+func accountingAPICIDRs(ctx context.Context, cc *config.ControllerConfiguration, host string) ([]string, error) {
+	if len(cc.NetworkPolicy.CIDRs) > 0 {
+		return cc.NetworkPolicy.CIDRs, nil
+	}
+
+	cidrs, err := endpoint.ResolveCIDRs(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve accounting-api host: %w", err)
+	}
+
+	return cidrs, nil
+}
+
+// accountingExporterNetworkPolicy only allows the egress traffic of the accounting-exporter to its accounting-api and the kube-apiserver
+// of the shoot and the ingress traffic from the prometheus of the shoot to the health port.
+//
+// The peers are still allowed by the gardener network policy labels of the accounting-exporter,
+// the policy replaces the label allowing the egress traffic to all public networks.
+func accountingExporterNetworkPolicy(cc *config.ControllerConfiguration, namespace, accountingAPIPort string, apiCIDRs []string) (*networkingv1.NetworkPolicy, error) {
+	accountingPort, err := strconv.Atoi(accountingAPIPort)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse accounting-api port: %w", err)
+	}
+
+	var (
+		tcp           = corev1.ProtocolTCP
+		apiPort       = intstr.FromInt(accountingPort)
+		apiServerPort = intstr.FromInt(kubeAPIServerPort)
+		exporterPort  = intstr.FromInt32(cc.Accounting.ExporterPort)
+		apiPeers      []networkingv1.NetworkPolicyPeer
+	)
+
+	for _, cidr := range apiCIDRs {
+		apiPeers = append(apiPeers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.AccountingExporterName,
+			Namespace: namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"k8s-app": "accounting-exporter",
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									v1beta1constants.LabelApp:  "prometheus",
+									v1beta1constants.LabelRole: v1beta1constants.LabelMonitoring,
+									"name":                     shootPrometheusName,
+								},
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &exporterPort},
+					},
+				},
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: apiPeers,
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &apiPort},
+					},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									v1beta1constants.LabelApp:  v1beta1constants.LabelKubernetes,
+									v1beta1constants.LabelRole: v1beta1constants.LabelAPIServer,
+								},
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &tcp, Port: &apiServerPort},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func TestAccountingAPICIDRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidrs   []string
+		host    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "address of the accounting-api host",
+			host: "10.0.0.1",
+			want: []string{"10.0.0.1/32"},
+		},
+		{
+			name:  "configured cidrs take precedence",
+			cidrs: []string{"10.0.0.0/24"},
+			host:  "10.0.0.1",
+			want:  []string{"10.0.0.0/24"},
+		},
+		{
+			name:    "unresolvable host",
+			host:    "accounting.invalid",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &config.ControllerConfiguration{NetworkPolicy: config.NetworkPolicy{Enabled: true, CIDRs: tt.cidrs}}
+
+			got, err := accountingAPICIDRs(context.Background(), cc, tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("accountingAPICIDRs() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("accountingAPICIDRs() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAccountingExporterNetworkPolicy(t *testing.T) {
+	cc := &config.ControllerConfiguration{Accounting: config.Accounting{ExporterPort: 3000}}
+
+	tests := []struct {
+		name        string
+		port        string
+		cidrs       []string
+		wantAPIPort int
+		wantCIDRs   []string
+		wantErr     bool
+	}{
+		{
+			name:        "egress to the addresses of the accounting-api",
+			port:        "9000",
+			cidrs:       []string{"10.0.0.1/32", "2001:db8::1/128"},
+			wantAPIPort: 9000,
+			wantCIDRs:   []string{"10.0.0.1/32", "2001:db8::1/128"},
+		},
+		{
+			name:    "invalid port",
+			port:    "http",
+			cidrs:   []string{"10.0.0.1/32"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := accountingExporterNetworkPolicy(cc, testNamespace, tt.port, tt.cidrs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("accountingExporterNetworkPolicy() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes); diff != "" {
+				t.Errorf("policy types diff (-want +got):\n%s", diff)
+			}
+
+			if len(policy.Spec.Egress) != 2 {
+				t.Fatalf("egress rules = %d, want the accounting-api and the kube-apiserver", len(policy.Spec.Egress))
+			}
+
+			api := policy.Spec.Egress[0]
+			if got := api.Ports[0].Port.IntValue(); got != tt.wantAPIPort {
+				t.Errorf("accounting-api port = %d, want %d", got, tt.wantAPIPort)
+			}
+			var cidrs []string
+			for _, peer := range api.To {
+				cidrs = append(cidrs, peer.IPBlock.CIDR)
+			}
+			if diff := cmp.Diff(tt.wantCIDRs, cidrs); diff != "" {
+				t.Errorf("accounting-api cidrs diff (-want +got):\n%s", diff)
+			}
+
+			if got := policy.Spec.Egress[1].Ports[0].Port.IntValue(); got != kubeAPIServerPort {
+				t.Errorf("kube-apiserver port = %d, want %d", got, kubeAPIServerPort)
+			}
+			if got := policy.Spec.Ingress[0].Ports[0].Port.IntValue(); got != int(cc.Accounting.ExporterPort) {
+				t.Errorf("ingress port = %d, want the exporter port %d", got, cc.Accounting.ExporterPort)
+			}
+		})
+	}
+}
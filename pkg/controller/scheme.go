@@ -0,0 +1,24 @@
+package controller
+
+import (
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/install"
+)
+
+// AddToScheme adds the types which are read and written by the controllers of the extension to the scheme of the manager.
+// The seed objects of the accounting-exporter are registered as well, e.g. the service monitor, the prometheus rules and
+// the vertical pod autoscaler.
+func AddToScheme(scheme *runtime.Scheme) error {
+	schemeBuilder := runtime.NewSchemeBuilder(
+		extensionscontroller.AddToScheme,
+		install.AddToScheme,
+		monitoringv1.AddToScheme,
+		vpaautoscalingv1.AddToScheme,
+	)
+
+	return schemeBuilder.AddToScheme(scheme)
+}
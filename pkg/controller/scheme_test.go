@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"testing"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/accounting/v1alpha1"
+)
+
+func TestAddToScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %s", err)
+	}
+
+	tests := []struct {
+		name string
+		obj  runtime.Object
+	}{
+		{name: "extension", obj: &extensionsv1alpha1.Extension{}},
+		{name: "managed resource", obj: &resourcesv1alpha1.ManagedResource{}},
+		{name: "deployment", obj: &appsv1.Deployment{}},
+		{name: "accounting config", obj: &v1alpha1.AccountingConfig{}},
+		{name: "service monitor", obj: &monitoringv1.ServiceMonitor{}},
+		{name: "prometheus rule", obj: &monitoringv1.PrometheusRule{}},
+		{name: "vertical pod autoscaler", obj: &vpaautoscalingv1.VerticalPodAutoscaler{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := apiutil.GVKForObject(tt.obj, scheme); err != nil {
+				t.Errorf("type is not registered: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+const (
+	secretsManagerIdentity = "extension-fits-accounting"
+
+	clientCAName         = "accounting-exporter-client-ca"
+	clientCertSecretName = "accounting-exporter-client"
+)
+
+// reconcileClientCertificate issues the client certificate of the shoot's accounting-exporter, which is signed by the configured client ca.
+// The certificate is renewed before it expires and re-issued when the client ca changes. As the name of the returned secret
+// changes in both cases, the accounting-exporter is rolled when it mounts the secret.
+func (a *actuator) reconcileClientCertificate(ctx context.Context, log logr.Logger, ca *config.ClientCA, cluster *controller.Cluster, namespace string) (*corev1.Secret, error) {
+	signingCA, err := secretsutils.LoadCertificate(clientCAName, []byte(ca.PrivateKey), []byte(ca.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load client ca: %w", err)
+	}
+
+	sm, err := secretsmanager.New(ctx, log.WithName("secretsmanager"), a.clock, a.client, namespace, secretsManagerIdentity, secretsmanager.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create secrets manager: %w", err)
+	}
+
+	secret, err := sm.Generate(ctx, &secretsutils.CertificateSecretConfig{
+		Name:                        clientCertSecretName,
+		CommonName:                  string(cluster.Shoot.UID),
+		CertType:                    secretsutils.ClientCert,
+		SigningCA:                   signingCA,
+		Validity:                    &ca.Validity.Duration,
+		SkipPublishingCACertificate: true,
+	}, secretsmanager.Rotate(secretsmanager.InPlace))
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate client certificate: %w", err)
+	}
+
+	if err := sm.Cleanup(ctx); err != nil {
+		return nil, fmt.Errorf("unable to clean up outdated client certificates: %w", err)
+	}
+
+	return secret, nil
+}
+
+// deleteClientCertificates removes all secrets issued by the secrets manager of the extension.
+func (a *actuator) deleteClientCertificates(ctx context.Context, namespace string) error {
+	return a.client.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(namespace), client.MatchingLabels{
+		secretsmanager.LabelKeyManagedBy:       secretsmanager.LabelValueSecretsManager,
+		secretsmanager.LabelKeyManagerIdentity: secretsManagerIdentity,
+	})
+}
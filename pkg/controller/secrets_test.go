@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func testClientCA(t *testing.T) *config.ClientCA {
+	t.Helper()
+
+	ca, err := (&secretsutils.CertificateSecretConfig{
+		Name:       "client-ca",
+		CommonName: "client-ca",
+		CertType:   secretsutils.CACert,
+	}).GenerateCertificate()
+	if err != nil {
+		t.Fatalf("unable to generate ca: %s", err)
+	}
+
+	return &config.ClientCA{
+		Certificate: string(ca.CertificatePEM),
+		PrivateKey:  string(ca.PrivateKeyPEM),
+		Validity:    &metav1.Duration{Duration: 30 * 24 * time.Hour},
+	}
+}
+
+func TestReconcileClientCertificate(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		a       = newTestActuator(t)
+		cluster = &controller.Cluster{
+			Shoot: &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "shoot-uid"}},
+		}
+		ca      = testClientCA(t)
+		otherCA = testClientCA(t)
+	)
+
+	verify := func(t *testing.T, secret *corev1.Secret, ca *config.ClientCA) {
+		t.Helper()
+
+		block, _ := pem.Decode(secret.Data[secretsutils.DataKeyCertificate])
+		if block == nil {
+			t.Fatalf("secret %s does not contain a certificate", secret.Name)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("unable to parse certificate: %s", err)
+		}
+
+		if cert.Subject.CommonName != "shoot-uid" {
+			t.Errorf("common name = %q, want the uid of the shoot", cert.Subject.CommonName)
+		}
+
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM([]byte(ca.Certificate))
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+			t.Errorf("certificate is not signed by the client ca: %s", err)
+		}
+	}
+
+	// the steps run in order against the same seed
+	steps := []struct {
+		name        string
+		ca          *config.ClientCA
+		wantRenamed bool
+	}{
+		{name: "issues the client certificate", ca: ca},
+		{name: "keeps the client certificate", ca: ca},
+		{name: "re-issues the client certificate when the ca changes", ca: otherCA, wantRenamed: true},
+	}
+
+	var previous *corev1.Secret
+	for _, step := range steps {
+		secret, err := a.reconcileClientCertificate(ctx, logr.Discard(), step.ca, cluster, testNamespace)
+		if err != nil {
+			t.Fatalf("%s: reconcileClientCertificate() error = %s", step.name, err)
+		}
+
+		verify(t, secret, step.ca)
+
+		if previous != nil && (secret.Name != previous.Name) != step.wantRenamed {
+			t.Errorf("%s: secret name changed from %s to %s, want renamed %t", step.name, previous.Name, secret.Name, step.wantRenamed)
+		}
+
+		secrets := &corev1.SecretList{}
+		if err := a.client.List(ctx, secrets, client.InNamespace(testNamespace)); err != nil {
+			t.Fatalf("unable to list secrets: %s", err)
+		}
+		for _, s := range secrets.Items {
+			if s.Name != secret.Name && s.Labels[secretsmanager.LabelKeyName] == clientCertSecretName {
+				t.Errorf("%s: outdated client certificate %s was not cleaned up", step.name, s.Name)
+			}
+		}
+
+		previous = secret
+	}
+}
@@ -0,0 +1,336 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config/validation"
+)
+
+const (
+	// KeyMetalHMAC is the key of the metal-api hmac in the credentials source.
+	KeyMetalHMAC = "metalHMAC"
+	// KeyCA is the key of the accounting-api ca in the credentials source.
+	KeyCA = "ca"
+	// KeyClientCert is the key of the shared client certificate in the credentials source.
+	KeyClientCert = "cert"
+	// KeyClientKey is the key of the shared client key in the credentials source.
+	KeyClientKey = "key"
+	// KeyClientCACert is the key of the client ca certificate in the credentials source.
+	KeyClientCACert = "clientCACert"
+	// KeyClientCAKey is the key of the client ca private key in the credentials source.
+	KeyClientCAKey = "clientCAKey"
+	// KeyHMAC is the key of the hmac of a further metal-api, see MetalAPIKey.
+	KeyHMAC = "hmac"
+
+	// rewatchInterval is the interval in which a closed or failed watch of the credentials secret is re-established
+	rewatchInterval = 10 * time.Second
+)
+
+// PartitionKey returns the key of the given credential (ca, cert or key) of the accounting-api of a partition
+// in the credentials source, e.g. partition.partition-b.ca.
+func PartitionKey(partition, key string) string {
+	return "partition." + partition + "." + key
+}
+
+// MetalAPIKey returns the key of the given credential (hmac) of a further metal-api in the credentials source,
+// e.g. metalAPI.region-b.hmac.
+func MetalAPIKey(name, key string) string {
+	return "metalAPI." + name + "." + key
+}
+
+// Store holds the current credentials of the accounting. If the configuration references a credentials source,
+// the credentials are read from it and reloaded when they change. Otherwise the inline credentials are used.
+type Store struct {
+	accounting config.Accounting
+	client     client.WithWatch
+	onChange   func(context.Context) error
+
+	mu          sync.RWMutex
+	credentials map[string]string
+}
+
+// NewStore returns a store for the credentials of the given accounting configuration. The client is used to read and
+// to watch a referenced secret, onChange is called after the credentials were reloaded with a different content.
+func NewStore(accounting config.Accounting, c client.WithWatch, onChange func(context.Context) error) *Store {
+	return &Store{
+		accounting: accounting,
+		client:     c,
+		onChange:   onChange,
+	}
+}
+
+// Apply sets the current credentials on the given accounting configuration.
+func (s *Store) Apply(accounting *config.Accounting) {
+	if s.accounting.CredentialsSource == nil {
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	apply(accounting, s.credentials)
+}
+
+// Load reads the credentials from the credentials source and returns whether they changed.
+// Invalid credentials are not taken over.
+func (s *Store) Load(ctx context.Context) (bool, error) {
+	source := s.accounting.CredentialsSource
+	if source == nil {
+		return false, nil
+	}
+
+	var (
+		credentials map[string]string
+		err         error
+	)
+	switch {
+	case source.SecretRef != nil:
+		credentials, err = s.readSecret(ctx, source.SecretRef)
+	case source.Path != nil:
+		credentials, err = s.readDir(*source.Path)
+	default:
+		return false, errors.New("credentials source references neither a secret nor a path")
+	}
+	if err != nil {
+		return false, err
+	}
+
+	accounting := *s.accounting.DeepCopy()
+	apply(&accounting, credentials)
+	if errs := validation.ValidateCredentials(&accounting, field.NewPath("credentialsSource")); len(errs) > 0 {
+		return false, fmt.Errorf("invalid credentials: %w", errs.ToAggregate())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if equal(s.credentials, credentials) {
+		return false, nil
+	}
+
+	s.credentials = credentials
+
+	return true, nil
+}
+
+// Start watches the credentials source and reloads the credentials whenever it changes until the context is done.
+// A referenced secret is watched in the api server, a directory is watched in the file system.
+func (s *Store) Start(ctx context.Context) error {
+	source := s.accounting.CredentialsSource
+	if source == nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx).WithName("credentials")
+
+	if source.SecretRef != nil {
+		s.watchSecret(ctx, log, source.SecretRef)
+		return nil
+	}
+
+	return s.watchDir(ctx, log, *source.Path)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The credentials are only used by the controllers, which
+// run on the leader, so only the leader reloads them and requests the reconciliation of the extensions.
+func (s *Store) NeedLeaderElection() bool {
+	return true
+}
+
+// reload loads the credentials and calls onChange if they changed. Errors are only logged, the current credentials are kept.
+func (s *Store) reload(ctx context.Context, log logr.Logger) {
+	changed, err := s.Load(ctx)
+	if err != nil {
+		log.Error(err, "unable to reload credentials, keeping the current ones")
+		return
+	}
+	if !changed {
+		return
+	}
+
+	log.Info("credentials changed")
+
+	if s.onChange != nil {
+		if err := s.onChange(ctx); err != nil {
+			log.Error(err, "unable to propagate changed credentials")
+		}
+	}
+}
+
+// watchSecret reloads the credentials on every event of the referenced secret. The watch is re-established when it
+// is closed by the api server. The credentials are reloaded whenever the watch was established, as they might have
+// changed before, e.g. while this replica was waiting for the leadership.
+func (s *Store) watchSecret(ctx context.Context, log logr.Logger, ref *corev1.SecretReference) {
+	for {
+		w, err := s.client.Watch(ctx, &corev1.SecretList{}, client.InNamespace(ref.Namespace), client.MatchingFields{"metadata.name": ref.Name})
+		if err != nil {
+			log.Error(err, "unable to watch credentials secret")
+		} else {
+			s.reload(ctx, log)
+			s.reloadOnEvents(ctx, log, w)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rewatchInterval):
+		}
+	}
+}
+
+func (s *Store) reloadOnEvents(ctx context.Context, log logr.Logger, w watch.Interface) {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			s.reload(ctx, log)
+		}
+	}
+}
+
+// watchDir reloads the credentials on every change in the given directory. The directory is watched instead of the
+// files, as a mounted secret is updated by replacing the symlink to its data.
+func (s *Store) watchDir(ctx context.Context, log logr.Logger, path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create credentials watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(path); err != nil {
+		return fmt.Errorf("unable to watch credentials directory: %w", err)
+	}
+
+	// the credentials might have changed before, e.g. while this replica was waiting for the leadership
+	s.reload(ctx, log)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			s.reload(ctx, log)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error(err, "error watching credentials directory")
+		}
+	}
+}
+
+// keys returns the keys of the credentials source, which depend on the configured partitions and metal-apis.
+func (s *Store) keys() []string {
+	keys := []string{KeyMetalHMAC, KeyCA, KeyClientCert, KeyClientKey, KeyClientCACert, KeyClientCAKey}
+
+	for _, api := range s.accounting.MetalAPIs {
+		keys = append(keys, MetalAPIKey(api.Name, KeyHMAC))
+	}
+
+	for partition := range s.accounting.Partitions {
+		keys = append(keys, PartitionKey(partition, KeyCA), PartitionKey(partition, KeyClientCert), PartitionKey(partition, KeyClientKey))
+	}
+
+	return keys
+}
+
+func (s *Store) readSecret(ctx context.Context, ref *corev1.SecretReference) (map[string]string, error) {
+	secret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("unable to read credentials secret: %w", err)
+	}
+
+	credentials := map[string]string{}
+	for _, key := range s.keys() {
+		if value, ok := secret.Data[key]; ok {
+			credentials[key] = string(value)
+		}
+	}
+
+	return credentials, nil
+}
+
+func (s *Store) readDir(path string) (map[string]string, error) {
+	credentials := map[string]string{}
+	for _, key := range s.keys() {
+		value, err := os.ReadFile(filepath.Join(path, key))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to read credentials file: %w", err)
+		}
+		credentials[key] = string(value)
+	}
+
+	return credentials, nil
+}
+
+func apply(accounting *config.Accounting, credentials map[string]string) {
+	accounting.MetalHMAC = credentials[KeyMetalHMAC]
+	accounting.CA = credentials[KeyCA]
+	accounting.ClientCert = credentials[KeyClientCert]
+	accounting.ClientKey = credentials[KeyClientKey]
+
+	if accounting.ClientCA != nil {
+		ca := *accounting.ClientCA
+		ca.Certificate = credentials[KeyClientCACert]
+		ca.PrivateKey = credentials[KeyClientCAKey]
+		accounting.ClientCA = &ca
+	}
+
+	// the metal-apis and the partitions are copied, as they are shared with the configuration the credentials are applied to
+	metalAPIs := slices.Clone(accounting.MetalAPIs)
+	for i := range metalAPIs {
+		metalAPIs[i].HMAC = credentials[MetalAPIKey(metalAPIs[i].Name, KeyHMAC)]
+	}
+	accounting.MetalAPIs = metalAPIs
+
+	if accounting.Partitions != nil {
+		partitions := make(map[string]config.AccountingEndpoint, len(accounting.Partitions))
+		for partition, endpoint := range accounting.Partitions {
+			endpoint.CA = credentials[PartitionKey(partition, KeyCA)]
+			endpoint.ClientCert = credentials[PartitionKey(partition, KeyClientCert)]
+			endpoint.ClientKey = credentials[PartitionKey(partition, KeyClientKey)]
+			partitions[partition] = endpoint
+		}
+		accounting.Partitions = partitions
+	}
+}
+
+func equal(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+package credentials_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+)
+
+var (
+	certificatesOnce sync.Once
+	testCA           *secretsutils.Certificate
+	testClient       *secretsutils.Certificate
+)
+
+// testCertificates generates the certificates used by the tests only once, as generating the keys is slow.
+func testCertificates(t *testing.T) (ca, client *secretsutils.Certificate) {
+	t.Helper()
+
+	certificatesOnce.Do(func() {
+		var err error
+		testCA, err = (&secretsutils.CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: secretsutils.CACert}).GenerateCertificate()
+		if err != nil {
+			t.Fatalf("unable to generate ca: %s", err)
+		}
+		testClient, err = (&secretsutils.CertificateSecretConfig{Name: "client", CommonName: "client", CertType: secretsutils.ClientCert, SigningCA: testCA}).GenerateCertificate()
+		if err != nil {
+			t.Fatalf("unable to generate client certificate: %s", err)
+		}
+	})
+
+	return testCA, testClient
+}
+
+// testAccounting returns an accounting configuration with a further metal-api and a partition, whose credentials are
+// read from the given credentials source.
+func testAccounting(source *config.CredentialsSource) config.Accounting {
+	return config.Accounting{
+		MetalAPIs: []config.MetalAPI{{Name: "region-b", URL: "https://metal-b.example.com", HMAC: "inline", Regions: []string{"region-b"}}},
+		Partitions: map[string]config.AccountingEndpoint{
+			"partition-b": {AccountingHost: "accounting-b.example.com"},
+		},
+		ProjectResolver:   config.ProjectResolver{Type: config.ProjectResolverTypeMetal},
+		CredentialsSource: source,
+	}
+}
+
+// testCredentials returns valid credentials for the test accounting configuration, the hmac of the further metal-api is given.
+func testCredentials(t *testing.T, hmac string) map[string]string {
+	t.Helper()
+
+	ca, client := testCertificates(t)
+
+	return map[string]string{
+		credentials.KeyCA:         string(ca.CertificatePEM),
+		credentials.KeyClientCert: string(client.CertificatePEM),
+		credentials.KeyClientKey:  string(client.PrivateKeyPEM),
+
+		credentials.MetalAPIKey("region-b", credentials.KeyHMAC):           hmac,
+		credentials.PartitionKey("partition-b", credentials.KeyCA):         string(ca.CertificatePEM),
+		credentials.PartitionKey("partition-b", credentials.KeyClientCert): string(client.CertificatePEM),
+		credentials.PartitionKey("partition-b", credentials.KeyClientKey):  string(client.PrivateKeyPEM),
+
+		// keys which do not belong to the configuration are ignored
+		"unrelated": "ignored",
+		credentials.PartitionKey("partition-unknown", credentials.KeyCA): "ignored",
+		credentials.MetalAPIKey("region-unknown", credentials.KeyHMAC):   "ignored",
+	}
+}
+
+func credentialsSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "extension-accounting"},
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+func writeCredentials(t *testing.T, dir string, data map[string]string) {
+	t.Helper()
+
+	for key, value := range data {
+		if err := os.WriteFile(filepath.Join(dir, key), []byte(value), 0600); err != nil {
+			t.Fatalf("unable to write credentials: %s", err)
+		}
+	}
+}
+
+func TestStoreLoad(t *testing.T) {
+	ca, client := testCertificates(t)
+
+	tests := []struct {
+		name        string
+		credentials map[string]string
+		wantChanged bool
+		wantErr     bool
+		want        config.Accounting
+	}{
+		{
+			name:        "valid credentials",
+			credentials: testCredentials(t, "hmac"),
+			wantChanged: true,
+			want: config.Accounting{
+				CA:         string(ca.CertificatePEM),
+				ClientCert: string(client.CertificatePEM),
+				ClientKey:  string(client.PrivateKeyPEM),
+				MetalAPIs:  []config.MetalAPI{{Name: "region-b", URL: "https://metal-b.example.com", HMAC: "hmac", Regions: []string{"region-b"}}},
+				Partitions: map[string]config.AccountingEndpoint{
+					"partition-b": {
+						AccountingHost: "accounting-b.example.com",
+						CA:             string(ca.CertificatePEM),
+						ClientCert:     string(client.CertificatePEM),
+						ClientKey:      string(client.PrivateKeyPEM),
+					},
+				},
+			},
+		},
+		{
+			name:        "missing hmac of a further metal-api",
+			credentials: testCredentials(t, ""),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &config.CredentialsSource{SecretRef: &corev1.SecretReference{Name: "credentials", Namespace: "extension-accounting"}}
+			accounting := testAccounting(source)
+
+			c := fake.NewClientBuilder().WithObjects(credentialsSecret(tt.credentials)).Build()
+			store := credentials.NewStore(accounting, c, nil)
+
+			changed, err := store.Load(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("Load() changed = %t, want %t", changed, tt.wantChanged)
+			}
+			if err != nil {
+				return
+			}
+
+			got := testAccounting(source)
+			store.Apply(&got)
+
+			tt.want.ProjectResolver = accounting.ProjectResolver
+			tt.want.CredentialsSource = source
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Apply() diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(testAccounting(source), accounting); diff != "" {
+				t.Errorf("Apply() modified the configuration of the store (-want +got):\n%s", diff)
+			}
+
+			changed, err = store.Load(context.Background())
+			if err != nil || changed {
+				t.Errorf("Load() of unchanged credentials = %t, %v, want false, nil", changed, err)
+			}
+		})
+	}
+}
+
+func TestStoreStart(t *testing.T) {
+	tests := []struct {
+		name   string
+		source func(t *testing.T) (*config.CredentialsSource, client.WithWatch)
+		update func(t *testing.T, source *config.CredentialsSource, c client.WithWatch, data map[string]string)
+	}{
+		{
+			name: "secret is watched",
+			source: func(t *testing.T) (*config.CredentialsSource, client.WithWatch) {
+				source := &config.CredentialsSource{SecretRef: &corev1.SecretReference{Name: "credentials", Namespace: "extension-accounting"}}
+				return source, fake.NewClientBuilder().WithObjects(credentialsSecret(testCredentials(t, "hmac"))).Build()
+			},
+			update: func(t *testing.T, _ *config.CredentialsSource, c client.WithWatch, data map[string]string) {
+				if err := c.Update(context.Background(), credentialsSecret(data)); err != nil {
+					t.Fatalf("unable to update credentials secret: %s", err)
+				}
+			},
+		},
+		{
+			name: "directory is watched",
+			source: func(t *testing.T) (*config.CredentialsSource, client.WithWatch) {
+				dir := t.TempDir()
+				writeCredentials(t, dir, testCredentials(t, "hmac"))
+				return &config.CredentialsSource{Path: &dir}, nil
+			},
+			update: func(t *testing.T, source *config.CredentialsSource, _ client.WithWatch, data map[string]string) {
+				writeCredentials(t, *source.Path, data)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			source, c := tt.source(t)
+			accounting := testAccounting(source)
+
+			changes := make(chan struct{}, 10)
+			store := credentials.NewStore(accounting, c, func(context.Context) error {
+				changes <- struct{}{}
+				return nil
+			})
+			if _, err := store.Load(ctx); err != nil {
+				t.Fatalf("Load() error = %s", err)
+			}
+
+			done := make(chan error)
+			go func() { done <- store.Start(ctx) }()
+
+			tt.update(t, source, c, testCredentials(t, "rotated"))
+
+			select {
+			case <-changes:
+			case <-time.After(5 * time.Second):
+				t.Fatal("changed credentials were not reloaded")
+			}
+
+			got := testAccounting(source)
+			store.Apply(&got)
+			if got.MetalAPIs[0].HMAC != "rotated" {
+				t.Errorf("hmac = %q, want the rotated one", got.MetalAPIs[0].HMAC)
+			}
+
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("Start() error = %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+// ResolveCIDRs returns the single host networks of the addresses of the given host.
+// The host may also be an ip address, it is returned without a lookup then.
+func ResolveCIDRs(ctx context.Context, host string) ([]string, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve host %q: %w", host, err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("host %q did not resolve to any address", host)
+	}
+
+	var cidrs []string
+	for _, ip := range ips {
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		cidrs = append(cidrs, (&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}).String())
+	}
+
+	// the resolver may return the addresses in any order, sorting avoids needless updates of the objects using them
+	slices.Sort(cidrs)
+
+	return slices.Compact(cidrs), nil
+}
+
+// ForPartition returns the accounting-api the accounting-exporters of the shoots in the given partition report to.
+// The settings which are not configured for the partition are taken from the default accounting-api. The client certificate
+// is empty if the shoots report with a client certificate issued by the client ca.
+func ForPartition(accounting *config.Accounting, partitionID string) config.AccountingEndpoint {
+	endpoint := config.AccountingEndpoint{
+		AccountingHost: accounting.AccountingHost,
+		AccountingPort: accounting.AccountingPort,
+		CA:             accounting.CA,
+	}
+	if accounting.ClientCA == nil {
+		endpoint.ClientCert = accounting.ClientCert
+		endpoint.ClientKey = accounting.ClientKey
+	}
+
+	partition, ok := accounting.Partitions[partitionID]
+	if !ok {
+		return endpoint
+	}
+
+	endpoint.AccountingHost = partition.AccountingHost
+	if partition.AccountingPort != "" {
+		endpoint.AccountingPort = partition.AccountingPort
+	}
+	if partition.CA != "" {
+		endpoint.CA = partition.CA
+	}
+	if partition.ClientCert != "" {
+		endpoint.ClientCert = partition.ClientCert
+		endpoint.ClientKey = partition.ClientKey
+	}
+
+	return endpoint
+}
+
+// All returns the default accounting-api followed by the accounting-apis of all partitions, ordered by partition.
+func All(accounting *config.Accounting) []config.AccountingEndpoint {
+	endpoints := []config.AccountingEndpoint{ForPartition(accounting, "")}
+
+	for _, partition := range slices.Sorted(maps.Keys(accounting.Partitions)) {
+		endpoints = append(endpoints, ForPartition(accounting, partition))
+	}
+
+	return endpoints
+}
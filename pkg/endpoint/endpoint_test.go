@@ -0,0 +1,136 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func testAccounting() *config.Accounting {
+	return &config.Accounting{
+		AccountingHost: "accounting.example.com",
+		AccountingPort: "9000",
+		CA:             "ca",
+		ClientCert:     "cert",
+		ClientKey:      "key",
+		Partitions: map[string]config.AccountingEndpoint{
+			"partition-b": {
+				AccountingHost: "accounting-b.example.com",
+			},
+			"partition-a": {
+				AccountingHost: "accounting-a.example.com",
+				AccountingPort: "9001",
+				CA:             "ca-a",
+				ClientCert:     "cert-a",
+				ClientKey:      "key-a",
+			},
+		},
+	}
+}
+
+func TestForPartition(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		clientCA  bool
+		want      config.AccountingEndpoint
+	}{
+		{
+			name:      "default accounting-api",
+			partition: "partition-c",
+			want:      config.AccountingEndpoint{AccountingHost: "accounting.example.com", AccountingPort: "9000", CA: "ca", ClientCert: "cert", ClientKey: "key"},
+		},
+		{
+			name:      "accounting-api of the partition",
+			partition: "partition-a",
+			want:      config.AccountingEndpoint{AccountingHost: "accounting-a.example.com", AccountingPort: "9001", CA: "ca-a", ClientCert: "cert-a", ClientKey: "key-a"},
+		},
+		{
+			name:      "partition inherits the settings of the default accounting-api",
+			partition: "partition-b",
+			want:      config.AccountingEndpoint{AccountingHost: "accounting-b.example.com", AccountingPort: "9000", CA: "ca", ClientCert: "cert", ClientKey: "key"},
+		},
+		{
+			name:      "client certificate is issued by the client ca",
+			partition: "partition-b",
+			clientCA:  true,
+			want:      config.AccountingEndpoint{AccountingHost: "accounting-b.example.com", AccountingPort: "9000", CA: "ca"},
+		},
+		{
+			name:      "own client certificate of the partition with the client ca",
+			partition: "partition-a",
+			clientCA:  true,
+			want:      config.AccountingEndpoint{AccountingHost: "accounting-a.example.com", AccountingPort: "9001", CA: "ca-a", ClientCert: "cert-a", ClientKey: "key-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounting := testAccounting()
+			if tt.clientCA {
+				accounting.ClientCA = &config.ClientCA{}
+			}
+
+			if diff := cmp.Diff(tt.want, ForPartition(accounting, tt.partition)); diff != "" {
+				t.Errorf("ForPartition() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	var hosts []string
+	for _, endpoint := range All(testAccounting()) {
+		hosts = append(hosts, endpoint.AccountingHost)
+	}
+
+	want := []string{"accounting.example.com", "accounting-a.example.com", "accounting-b.example.com"}
+	if diff := cmp.Diff(want, hosts); diff != "" {
+		t.Errorf("All() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveCIDRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ipv4 address",
+			host: "10.0.0.1",
+			want: []string{"10.0.0.1/32"},
+		},
+		{
+			name: "ipv6 address",
+			host: "2001:db8::1",
+			want: []string{"2001:db8::1/128"},
+		},
+		{
+			name: "ipv4 mapped ipv6 address",
+			host: "::ffff:10.0.0.1",
+			want: []string{"10.0.0.1/32"},
+		},
+		{
+			name:    "unresolvable host",
+			host:    "accounting.invalid",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveCIDRs(context.Background(), tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveCIDRs() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ResolveCIDRs() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
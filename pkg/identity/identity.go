@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ProviderTypeMetal is the provider type of metal-stack shoots.
+const ProviderTypeMetal = "metal"
+
+// Identity contains the partition and the project of a shoot which are passed to the accounting-exporter.
+type Identity struct {
+	// PartitionID is the partition the shoot runs in
+	PartitionID string
+	// ProjectID is the id of the project the shoot belongs to
+	ProjectID string
+}
+
+// Resolver determines the identity of a shoot.
+type Resolver interface {
+	// Resolve returns the identity of the shoot of the cluster.
+	Resolve(cluster *controller.Cluster) (*Identity, error)
+}
+
+// New returns the identity resolver for shoots of the given provider type.
+// The identity of metal-stack shoots is read from their infrastructure config, the one of all other shoots from their labels.
+func New(providerType string, decoder runtime.Decoder) Resolver {
+	switch providerType {
+	case ProviderTypeMetal:
+		return NewMetalResolver(decoder)
+	default:
+		return NewLabelResolver()
+	}
+}
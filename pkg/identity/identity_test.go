@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		providerType string
+		want         Resolver
+	}{
+		{providerType: "metal", want: &metalResolver{}},
+		{providerType: "local", want: &labelResolver{}},
+		{providerType: "openstack", want: &labelResolver{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.providerType, func(t *testing.T) {
+			if got := New(tt.providerType, nil); reflect.TypeOf(got) != reflect.TypeOf(tt.want) {
+				t.Errorf("New() = %T, want %T", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelResolver(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   *Identity
+	}{
+		{
+			name: "region and gardener project",
+			want: &Identity{PartitionID: "region-a", ProjectID: "test"},
+		},
+		{
+			name: "labels of the shoot",
+			labels: map[string]string{
+				PartitionLabel: "partition-a",
+				ProjectIDLabel: "p1",
+			},
+			want: &Identity{PartitionID: "partition-a", ProjectID: "p1"},
+		},
+		{
+			name:   "empty labels fall back",
+			labels: map[string]string{PartitionLabel: "", ProjectIDLabel: ""},
+			want:   &Identity{PartitionID: "region-a", ProjectID: "test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &controller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-test", Labels: tt.labels},
+					Spec:       gardencorev1beta1.ShootSpec{Region: "region-a"},
+				},
+			}
+
+			got, err := NewLabelResolver().Resolve(cluster)
+			if err != nil {
+				t.Fatalf("Resolve() error = %s", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Resolve() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
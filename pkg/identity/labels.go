@@ -0,0 +1,45 @@
+package identity
+
+import (
+	"strings"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+)
+
+const (
+	// PartitionLabel is looked up on the shoot to determine the partition of a cluster of a provider other than metal-stack.
+	// If it is not present, the region of the shoot is used.
+	PartitionLabel = "accounting.fits.extensions.gardener.cloud/partition"
+	// ProjectIDLabel is looked up on the shoot to determine the project of a cluster of a provider other than metal-stack.
+	// If it is not present, the name of the gardener project is used.
+	ProjectIDLabel = "accounting.fits.extensions.gardener.cloud/project-id"
+)
+
+type labelResolver struct{}
+
+// NewLabelResolver returns an identity resolver which reads the partition and the project from the labels of the shoot.
+func NewLabelResolver() Resolver {
+	return &labelResolver{}
+}
+
+// Resolve implements Resolver.
+func (r *labelResolver) Resolve(cluster *controller.Cluster) (*Identity, error) {
+	shoot := cluster.Shoot
+
+	partition := shoot.Labels[PartitionLabel]
+	if partition == "" {
+		partition = shoot.Spec.Region
+	}
+
+	projectID := shoot.Labels[ProjectIDLabel]
+	if projectID == "" {
+		// the shoots of a gardener project are in the project namespace, which is usually named after the project
+		projectID = strings.TrimPrefix(shoot.Namespace, gutil.ProjectNamespacePrefix)
+	}
+
+	return &Identity{
+		PartitionID: partition,
+		ProjectID:   projectID,
+	}, nil
+}
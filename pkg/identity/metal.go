@@ -0,0 +1,36 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	metalhelper "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/helper"
+	metalv1alpha1 "github.com/metal-stack/gardener-extension-provider-metal/pkg/apis/metal/v1alpha1"
+)
+
+type metalResolver struct {
+	decoder runtime.Decoder
+}
+
+// NewMetalResolver returns an identity resolver which reads the partition and the project from the infrastructure config
+// of metal-stack shoots.
+func NewMetalResolver(decoder runtime.Decoder) Resolver {
+	return &metalResolver{
+		decoder: decoder,
+	}
+}
+
+// Resolve implements Resolver.
+func (r *metalResolver) Resolve(cluster *controller.Cluster) (*Identity, error) {
+	infrastructureConfig := &metalv1alpha1.InfrastructureConfig{}
+	if err := metalhelper.DecodeRawExtension(cluster.Shoot.Spec.Provider.InfrastructureConfig, infrastructureConfig, r.decoder); err != nil {
+		return nil, fmt.Errorf("unable decoding infrastructure config: %w", err)
+	}
+
+	return &Identity{
+		PartitionID: infrastructureConfig.PartitionID,
+		ProjectID:   infrastructureConfig.ProjectID,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "gardener_extension_accounting"
+
+	// ResultSuccess is the result label value of successful operations.
+	ResultSuccess = "success"
+	// ResultError is the result label value of failed operations.
+	ResultError = "error"
+
+	// collectTimeout is the time a collector has for computing its metrics on a scrape.
+	collectTimeout = 10 * time.Second
+)
+
+var (
+	// Operations counts the actuator operations per shoot namespace, operation and result.
+	Operations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operations_total",
+		Help:      "Total number of extension operations (reconcile, delete, force-delete, migrate, restore) by shoot namespace and result.",
+	}, []string{"shoot_namespace", "operation", "result"})
+
+	// MetalAPIRequestDuration observes the latency of the requests to the metal-apis.
+	MetalAPIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "metal_api_request_duration_seconds",
+		Help:      "Latency of the requests to the metal-apis by metal-api and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"metal_api", "operation"})
+
+	// MetalAPIRequestErrors counts the failed requests to the metal-apis.
+	MetalAPIRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "metal_api_request_errors_total",
+		Help:      "Total number of failed requests to the metal-apis by metal-api and operation.",
+	}, []string{"metal_api", "operation"})
+
+	// ProjectCacheRequests counts the project cache lookups by metal-api and result (hit, miss, not_found, stale).
+	ProjectCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "project_cache_requests_total",
+		Help:      "Total number of project cache lookups by metal-api and result.",
+	}, []string{"metal_api", "result"})
+
+	// ProjectCacheRefreshes counts the refreshes of all projects in the project caches.
+	ProjectCacheRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "project_cache_refreshes_total",
+		Help:      "Total number of project cache refreshes by metal-api and result.",
+	}, []string{"metal_api", "result"})
+
+	// ManagedResourceApplyDuration observes the duration of creating or updating the managed resources.
+	ManagedResourceApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "managed_resource_apply_duration_seconds",
+		Help:      "Duration of applying the managed resources of the extension.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name"})
+
+	// HibernatedExportersDesc describes the number of shoots whose accounting-exporter is scaled to zero because of hibernation.
+	HibernatedExportersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "hibernated_exporters"),
+		"Number of shoots with the accounting-exporter scaled to zero because of hibernation.",
+		nil, nil,
+	)
+
+	// HibernationTransitions counts the hibernation transitions of the shoots by transition (hibernated, woke_up).
+	HibernationTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "hibernation_transitions_total",
+		Help:      "Total number of hibernation transitions of the shoots by transition.",
+	}, []string{"transition"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		Operations,
+		MetalAPIRequestDuration,
+		MetalAPIRequestErrors,
+		ProjectCacheRequests,
+		ProjectCacheRefreshes,
+		ManagedResourceApplyDuration,
+		HibernationTransitions,
+	)
+}
+
+// Result returns the result label value for the given error.
+func Result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+// ObserveOperation counts an actuator operation for the given shoot namespace.
+func ObserveOperation(shootNamespace, operation string, err error) {
+	Operations.WithLabelValues(shootNamespace, operation, Result(err)).Inc()
+}
+
+// ObserveFinalOperation counts an actuator operation after which the extension is gone from the given shoot namespace.
+// Once the operation succeeded, the series of the shoot namespace are removed instead, as they would be kept forever otherwise.
+func ObserveFinalOperation(shootNamespace, operation string, err error) {
+	if err == nil {
+		Operations.DeletePartialMatch(prometheus.Labels{"shoot_namespace": shootNamespace})
+		return
+	}
+	ObserveOperation(shootNamespace, operation, err)
+}
+
+// ObserveMetalAPIRequest records the latency and the outcome of a request to the given metal-api which started at the given time.
+func ObserveMetalAPIRequest(metalAPI, operation string, start time.Time, err error) {
+	MetalAPIRequestDuration.WithLabelValues(metalAPI, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		MetalAPIRequestErrors.WithLabelValues(metalAPI, operation).Inc()
+	}
+}
+
+// hibernatedExportersCollector exposes the number of shoots whose accounting-exporter is scaled to zero because of hibernation.
+// The number is counted on every scrape, so it covers all shoots and not only those reconciled since the extension started.
+type hibernatedExportersCollector struct {
+	count func(ctx context.Context) (int, error)
+}
+
+// RegisterHibernatedExporters registers the collector of the number of shoots whose accounting-exporter is scaled to zero
+// because of hibernation, which is counted with the given function.
+func RegisterHibernatedExporters(count func(ctx context.Context) (int, error)) error {
+	return metrics.Registry.Register(&hibernatedExportersCollector{count: count})
+}
+
+// Describe implements prometheus.Collector.
+func (c *hibernatedExportersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- HibernatedExportersDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *hibernatedExportersCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	count, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(HibernatedExportersDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(HibernatedExportersDesc, prometheus.GaugeValue, float64(count))
+}
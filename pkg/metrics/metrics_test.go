@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// series returns the number of series the given collector exposes.
+func series(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric, 100)
+	c.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+func TestObserveFinalOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		err       error
+		want      int
+	}{
+		{
+			name:      "successful delete removes the series of the shoot",
+			operation: "delete",
+			want:      1,
+		},
+		{
+			name:      "successful migrate removes the series of the shoot",
+			operation: "migrate",
+			want:      1,
+		},
+		{
+			name:      "failed delete is counted",
+			operation: "delete",
+			err:       errors.New("error"),
+			want:      4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Operations.Reset()
+
+			ObserveOperation("shoot--test--test", "reconcile", nil)
+			ObserveOperation("shoot--test--test", "reconcile", errors.New("error"))
+			ObserveOperation("shoot--test--other", "reconcile", nil)
+
+			ObserveFinalOperation("shoot--test--test", tt.operation, tt.err)
+
+			if got := series(Operations); got != tt.want {
+				t.Errorf("number of series = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHibernatedExportersCollector(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   func(ctx context.Context) (int, error)
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "number of hibernated exporters",
+			count: func(context.Context) (int, error) { return 2, nil },
+			want:  2,
+		},
+		{
+			name:    "extensions cannot be listed",
+			count:   func(context.Context) (int, error) { return 0, errors.New("cache is not started") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan prometheus.Metric, 1)
+			(&hibernatedExportersCollector{count: tt.count}).Collect(ch)
+			close(ch)
+
+			m := &dto.Metric{}
+			err := (<-ch).Write(m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got := m.GetGauge().GetValue(); got != tt.want {
+				t.Errorf("hibernated exporters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TenantAnnotation is looked up on the shoot and its project to determine the tenant of a cluster.
+	TenantAnnotation = tag.ClusterTenant
+	// ProjectNameAnnotation is looked up on the shoot and its project to determine the project name of a cluster.
+	// If it is not present, the name of the gardener project is used.
+	ProjectNameAnnotation = "accounting.fits.extensions.gardener.cloud/project-name"
+)
+
+type gardenResolver struct {
+	client client.Client
+}
+
+// NewGardenResolver returns a project resolver which reads the project metadata from annotations
+// of the shoot and its project in the garden cluster.
+func NewGardenResolver(gardenClient client.Client) ProjectResolver {
+	return &gardenResolver{
+		client: gardenClient,
+	}
+}
+
+// Resolve implements ProjectResolver.
+func (r *gardenResolver) Resolve(ctx context.Context, cluster *controller.Cluster, projectID string) (*Project, error) {
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: cluster.Shoot.Namespace, Name: cluster.Shoot.Name}, shoot); err != nil {
+		return nil, fmt.Errorf("unable to get shoot from garden cluster: %w", err)
+	}
+
+	gardenProject, err := gutil.ProjectForNamespaceFromReader(ctx, r.client, shoot.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get project of shoot from garden cluster: %w", err)
+	}
+
+	tenant := annotationValue(TenantAnnotation, shoot, gardenProject)
+	if tenant == "" {
+		return nil, fmt.Errorf("neither shoot nor project are annotated with %q", TenantAnnotation)
+	}
+
+	name := annotationValue(ProjectNameAnnotation, shoot, gardenProject)
+	if name == "" {
+		name = gardenProject.Name
+	}
+
+	return &Project{
+		ID:       projectID,
+		Name:     name,
+		TenantID: tenant,
+	}, nil
+}
+
+// annotationValue returns the value of the first object annotated with the given key.
+func annotationValue(key string, objects ...client.Object) string {
+	for _, obj := range objects {
+		if value, ok := obj.GetAnnotations()[key]; ok && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
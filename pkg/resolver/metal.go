@@ -0,0 +1,345 @@
+package resolver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	metalgo "github.com/metal-stack/metal-go"
+	"github.com/metal-stack/metal-go/api/client/project"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	"k8s.io/utils/clock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/metrics"
+)
+
+// ErrProjectNotFound is returned when a project does not exist in the metal-api.
+var ErrProjectNotFound = errors.New("project not found")
+
+type metalResolver struct {
+	config *config.Accounting
+	// caches holds a project cache per metal-api by its name
+	caches map[string]*projectCache
+}
+
+// projectCache caches the projects of a single metal-api.
+type projectCache struct {
+	name      string
+	config    *config.Accounting
+	newClient func() (metalgo.Client, error)
+	clock     clock.Clock
+
+	// mu guards the fields below and serializes the calls to the metal-api,
+	// such that concurrent reconciliations do not fetch the projects multiple times
+	mu        sync.Mutex
+	lastFetch time.Time
+	projects  map[string]*projectEntry
+	notFound  map[string]time.Time
+}
+
+type projectEntry struct {
+	project *models.V1ProjectResponse
+	fetched time.Time
+}
+
+// NewMetalResolver returns a project resolver which looks up the projects in the metal-api of the shoot.
+// The metal-api is selected by the region or the cloud profile of the shoot, the default metal-api is used
+// for the shoots which do not match any of the configured metal-apis.
+//
+// All projects of a metal-api are fetched at once and refreshed after the project cache ttl. Projects which are
+// not contained in the last fetch are looked up individually, projects that do not exist are
+// remembered for the not found cache ttl.
+func NewMetalResolver(cfg *config.Accounting, credentials *credentials.Store) ProjectResolver {
+	r := &metalResolver{
+		config: cfg,
+		caches: map[string]*projectCache{},
+	}
+
+	if cfg.MetalURL != "" {
+		r.caches[config.DefaultMetalAPIName] = newProjectCache(config.DefaultMetalAPIName, cfg, func() (metalgo.Client, error) {
+			// the hmac of the default metal-api can be read from the credentials source
+			accounting := *cfg
+			credentials.Apply(&accounting)
+			return newMetalClient(accounting.MetalURL, accounting.MetalHMAC, accounting.MetalAuthType, accounting.MetalAuth)
+		})
+	}
+
+	for i, api := range cfg.MetalAPIs {
+		r.caches[api.Name] = newProjectCache(api.Name, cfg, func() (metalgo.Client, error) {
+			// the hmac of the further metal-apis can be read from the credentials source as well
+			accounting := *cfg
+			credentials.Apply(&accounting)
+			api := accounting.MetalAPIs[i]
+			return newMetalClient(api.URL, api.HMAC, api.AuthType, api.Auth)
+		})
+	}
+
+	return r
+}
+
+func newProjectCache(name string, cfg *config.Accounting, newClient func() (metalgo.Client, error)) *projectCache {
+	return &projectCache{
+		name:      name,
+		config:    cfg,
+		newClient: newClient,
+		clock:     clock.RealClock{},
+		projects:  map[string]*projectEntry{},
+		notFound:  map[string]time.Time{},
+	}
+}
+
+// Resolve implements ProjectResolver.
+func (r *metalResolver) Resolve(ctx context.Context, cluster *controller.Cluster, projectID string) (*Project, error) {
+	cache, err := r.cacheFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cache.get(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cluster project from metal-api %q: %w", cache.name, err)
+	}
+
+	return &Project{
+		ID:         projectID,
+		Name:       resp.Name,
+		TenantID:   resp.TenantID,
+		MetalAPI:   cache.name,
+		Dimensions: projectDimensions(r.config.ProjectResolver.Dimensions, resp.Meta),
+	}, nil
+}
+
+// projectDimensions picks the configured dimensions from the annotations and the labels of the project.
+// Dimensions which are neither set as annotation nor as label are omitted.
+func projectDimensions(dimensions []config.ProjectDimension, meta *models.V1Meta) map[string]string {
+	if len(dimensions) == 0 || meta == nil {
+		return nil
+	}
+
+	// metal labels are formatted as key=value
+	labels := map[string]string{}
+	for _, label := range meta.Labels {
+		key, value, _ := strings.Cut(label, "=")
+		labels[key] = value
+	}
+
+	result := map[string]string{}
+	for _, dimension := range dimensions {
+		if value, ok := meta.Annotations[dimension.Key]; ok {
+			result[dimension.Name] = value
+		} else if value, ok := labels[dimension.Key]; ok {
+			result[dimension.Name] = value
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// cacheFor returns the project cache of the first metal-api selecting the region or the cloud profile of the shoot,
+// or the one of the default metal-api.
+func (r *metalResolver) cacheFor(cluster *controller.Cluster) (*projectCache, error) {
+	region := cluster.Shoot.Spec.Region
+
+	var cloudProfile string
+	if cluster.CloudProfile != nil {
+		cloudProfile = cluster.CloudProfile.Name
+	}
+
+	for _, api := range r.config.MetalAPIs {
+		if slices.Contains(api.Regions, region) || slices.Contains(api.CloudProfiles, cloudProfile) {
+			return r.caches[api.Name], nil
+		}
+	}
+
+	cache, ok := r.caches[config.DefaultMetalAPIName]
+	if !ok {
+		return nil, fmt.Errorf("no metal-api is configured for region %q and cloud profile %q", region, cloudProfile)
+	}
+
+	return cache, nil
+}
+
+func (r *projectCache) get(ctx context.Context, id string) (*models.V1ProjectResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		log = logf.FromContext(ctx).WithValues("project", id, "metalAPI", r.name)
+		now = r.clock.Now()
+		ttl = r.config.ProjectCacheTTL.Duration
+	)
+
+	entry, cached := r.projects[id]
+	if cached && now.Sub(entry.fetched) < ttl {
+		metrics.ProjectCacheRequests.WithLabelValues(r.name, "hit").Inc()
+		return entry.project, nil
+	}
+
+	if since, ok := r.notFound[id]; ok && now.Sub(since) < r.notFoundTTL() {
+		metrics.ProjectCacheRequests.WithLabelValues(r.name, "not_found").Inc()
+		return nil, ErrProjectNotFound
+	}
+
+	metrics.ProjectCacheRequests.WithLabelValues(r.name, "miss").Inc()
+
+	mclient, err := r.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if now.Sub(r.lastFetch) >= ttl {
+		if err := r.fetchAll(ctx, mclient, now); err != nil {
+			log.Error(err, "unable to refresh project cache, looking up project individually")
+		} else if entry, ok := r.projects[id]; ok {
+			return entry.project, nil
+		}
+	}
+
+	start := time.Now()
+	resp, err := mclient.Project().FindProject(project.NewFindProjectParams().WithContext(ctx).WithID(id), nil)
+
+	var apiErr *project.FindProjectDefault
+	if errors.As(err, &apiErr) && apiErr.IsCode(http.StatusNotFound) {
+		metrics.ObserveMetalAPIRequest(r.name, "find_project", start, nil)
+		delete(r.projects, id)
+		r.notFound[id] = now
+		return nil, ErrProjectNotFound
+	}
+
+	metrics.ObserveMetalAPIRequest(r.name, "find_project", start, err)
+
+	if err != nil {
+		if cached && pointer.SafeDeref(r.config.ProjectCacheStaleWhileError) {
+			metrics.ProjectCacheRequests.WithLabelValues(r.name, "stale").Inc()
+			log.Error(err, "unable to fetch project from metal-api, using stale cache entry", "fetched", entry.fetched)
+			return entry.project, nil
+		}
+
+		return nil, fmt.Errorf("error fetching project: %w", err)
+	}
+
+	delete(r.notFound, id)
+	r.projects[id] = &projectEntry{project: resp.Payload, fetched: now}
+
+	return resp.Payload, nil
+}
+
+// fetchAll refreshes all projects. Cache entries of projects which were not returned are kept,
+// they are looked up individually on their next access.
+func (r *projectCache) fetchAll(ctx context.Context, mclient metalgo.Client, now time.Time) error {
+	start := time.Now()
+	projects, err := mclient.Project().ListProjects(project.NewListProjectsParams().WithContext(ctx), nil)
+	metrics.ObserveMetalAPIRequest(r.name, "list_projects", start, err)
+	metrics.ProjectCacheRefreshes.WithLabelValues(r.name, metrics.Result(err)).Inc()
+	if err != nil {
+		return fmt.Errorf("error fetching projects from metal-api: %w", err)
+	}
+
+	for _, p := range projects.Payload {
+		if p.Meta == nil {
+			continue
+		}
+		delete(r.notFound, p.Meta.ID)
+		r.projects[p.Meta.ID] = &projectEntry{project: p, fetched: now}
+	}
+
+	r.lastFetch = now
+
+	return nil
+}
+
+// newMetalClient creates a client for the metal-api, we need to lookup the project name from the metal-api
+// as we do not have it anywhere in the cluster spec.
+//
+// The credentials files are read on every call, such that rotated tokens and certificates are used by the next client.
+func newMetalClient(url, hmac, authType string, auth *config.MetalAPIAuth) (metalgo.Client, error) {
+	var opts []metalgo.ClientOption
+
+	if hmac != "" {
+		opts = append(opts, metalgo.HMACAuth(hmac, authType))
+	}
+
+	if auth != nil {
+		if auth.TokenFile != "" {
+			token, err := os.ReadFile(auth.TokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read metal-api token: %w", err)
+			}
+			opts = append(opts, metalgo.BearerToken(strings.TrimSpace(string(token))))
+		}
+
+		tlsConfig, err := metalTLSConfig(auth)
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			opts = append(opts, metalgo.TLSClientConfig(tlsConfig))
+		}
+	}
+
+	mclient, err := metalgo.NewClient(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metal client: %w", err)
+	}
+
+	return mclient, nil
+}
+
+// metalTLSConfig returns the tls config with the client certificate and the ca of the metal-api,
+// it is nil if neither of them is configured.
+func metalTLSConfig(auth *config.MetalAPIAuth) (*tls.Config, error) {
+	if auth.ClientCertFile == "" && auth.CAFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if auth.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(auth.ClientCertFile, auth.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load metal-api client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if auth.CAFile != "" {
+		ca, err := os.ReadFile(auth.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read metal-api ca: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("unable to parse metal-api ca from %q", auth.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (r *projectCache) notFoundTTL() time.Duration {
+	if r.config.ProjectNotFoundCacheTTL == nil {
+		return 0
+	}
+	return r.config.ProjectNotFoundCacheTTL.Duration
+}
@@ -0,0 +1,526 @@
+package resolver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	metalgo "github.com/metal-stack/metal-go"
+	"github.com/metal-stack/metal-go/api/client/project"
+	"github.com/metal-stack/metal-go/api/models"
+	"github.com/metal-stack/metal-lib/httperrors"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+)
+
+// fakeMetalAPI serves the project endpoints of the metal-api and records the requests it receives.
+type fakeMetalAPI struct {
+	mu       sync.Mutex
+	projects map[string]*models.V1ProjectResponse
+	// unlisted projects are only returned when they are looked up individually
+	unlisted map[string]bool
+	down     bool
+	requests []string
+}
+
+func newFakeMetalAPI(t *testing.T) (*fakeMetalAPI, *httptest.Server) {
+	t.Helper()
+
+	api := &fakeMetalAPI{
+		projects: map[string]*models.V1ProjectResponse{},
+		unlisted: map[string]bool{},
+	}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return api, server
+}
+
+func (f *fakeMetalAPI) addProject(id, name string, listed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.projects[id] = &models.V1ProjectResponse{Meta: &models.V1Meta{ID: id}, Name: name, TenantID: "tenant"}
+	f.unlisted[id] = !listed
+}
+
+func (f *fakeMetalAPI) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.down = down
+}
+
+func (f *fakeMetalAPI) takeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func (f *fakeMetalAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	id, individual := strings.CutPrefix(r.URL.Path, "/v1/project/")
+	if individual {
+		f.requests = append(f.requests, "find "+id)
+	} else {
+		f.requests = append(f.requests, "list")
+	}
+
+	// like in the metal-api, the http error responses are encoded as json strings as they implement encoding.TextMarshaler
+	if f.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(httperrors.NewHTTPError(http.StatusServiceUnavailable, errors.New("unavailable")))
+		return
+	}
+
+	if !individual {
+		var projects []*models.V1ProjectResponse
+		for id, p := range f.projects {
+			if !f.unlisted[id] {
+				projects = append(projects, p)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(projects)
+		return
+	}
+
+	p, ok := f.projects[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(httperrors.NotFound(errors.New("project not found")))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+func TestProjectCache(t *testing.T) {
+	api, server := newFakeMetalAPI(t)
+	api.addProject("p1", "one", true)
+	api.addProject("p2", "two", false)
+
+	clock := testclock.NewFakeClock(time.Now())
+
+	cache := newProjectCache("test", &config.Accounting{
+		ProjectCacheTTL:             &metav1.Duration{Duration: 30 * time.Minute},
+		ProjectNotFoundCacheTTL:     &metav1.Duration{Duration: time.Minute},
+		ProjectCacheStaleWhileError: pointer.Pointer(true),
+	}, func() (metalgo.Client, error) {
+		return newMetalClient(server.URL, "", "", nil)
+	})
+	cache.clock = clock
+
+	// the steps run in order and share the state of the cache
+	steps := []struct {
+		name         string
+		setup        func()
+		projectID    string
+		wantName     string
+		wantErr      bool
+		wantNotFound bool
+		wantRequests []string
+	}{
+		{
+			name:         "first access fetches all projects",
+			projectID:    "p1",
+			wantName:     "one",
+			wantRequests: []string{"list"},
+		},
+		{
+			name:      "cached project",
+			projectID: "p1",
+			wantName:  "one",
+		},
+		{
+			name:         "project missing in the last fetch is looked up individually",
+			projectID:    "p2",
+			wantName:     "two",
+			wantRequests: []string{"find p2"},
+		},
+		{
+			name:         "unknown project",
+			projectID:    "p3",
+			wantNotFound: true,
+			wantRequests: []string{"find p3"},
+		},
+		{
+			name:         "unknown project is remembered",
+			projectID:    "p3",
+			wantNotFound: true,
+		},
+		{
+			name:         "unknown project is looked up again after the not found ttl",
+			setup:        func() { clock.Step(2 * time.Minute) },
+			projectID:    "p3",
+			wantNotFound: true,
+			wantRequests: []string{"find p3"},
+		},
+		{
+			name: "stale project while the metal-api is unavailable",
+			setup: func() {
+				clock.Step(30 * time.Minute)
+				api.setDown(true)
+			},
+			projectID:    "p1",
+			wantName:     "one",
+			wantRequests: []string{"list", "find p1"},
+		},
+		{
+			name:         "uncached project while the metal-api is unavailable",
+			projectID:    "p3",
+			wantErr:      true,
+			wantRequests: []string{"list", "find p3"},
+		},
+		{
+			name: "projects are refreshed after the ttl",
+			setup: func() {
+				api.setDown(false)
+				api.addProject("p1", "uno", true)
+			},
+			projectID:    "p1",
+			wantName:     "uno",
+			wantRequests: []string{"list"},
+		},
+	}
+
+	for _, step := range steps {
+		if step.setup != nil {
+			step.setup()
+		}
+
+		got, err := cache.get(context.Background(), step.projectID)
+		switch {
+		case step.wantNotFound:
+			if !errors.Is(err, ErrProjectNotFound) {
+				t.Errorf("%s: get() error = %v, want %v", step.name, err, ErrProjectNotFound)
+			}
+		case (err != nil) != step.wantErr:
+			t.Errorf("%s: get() error = %v, wantErr %t", step.name, err, step.wantErr)
+		case err == nil && got.Name != step.wantName:
+			t.Errorf("%s: get() name = %q, want %q", step.name, got.Name, step.wantName)
+		}
+
+		if diff := cmp.Diff(step.wantRequests, api.takeRequests()); diff != "" {
+			t.Errorf("%s: metal-api requests diff (-want +got):\n%s", step.name, diff)
+		}
+	}
+}
+
+func TestMetalResolver(t *testing.T) {
+	defaultAPI, defaultServer := newFakeMetalAPI(t)
+	defaultAPI.addProject("p1", "default", true)
+	regionAPI, regionServer := newFakeMetalAPI(t)
+	regionAPI.addProject("p1", "region", true)
+
+	cfg := &config.Accounting{
+		MetalURL: defaultServer.URL,
+		MetalAPIs: []config.MetalAPI{
+			{
+				Name:          "region-b",
+				URL:           regionServer.URL,
+				Regions:       []string{"region-b"},
+				CloudProfiles: []string{"metal-b"},
+			},
+		},
+		ProjectCacheTTL: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+
+	tests := []struct {
+		name         string
+		withDefault  bool
+		region       string
+		cloudProfile string
+		wantMetalAPI string
+		wantName     string
+		wantErr      bool
+	}{
+		{
+			name:         "metal-api of the region",
+			withDefault:  true,
+			region:       "region-b",
+			wantMetalAPI: "region-b",
+			wantName:     "region",
+		},
+		{
+			name:         "metal-api of the cloud profile",
+			withDefault:  true,
+			region:       "region-c",
+			cloudProfile: "metal-b",
+			wantMetalAPI: "region-b",
+			wantName:     "region",
+		},
+		{
+			name:         "default metal-api for the other shoots",
+			withDefault:  true,
+			region:       "region-a",
+			wantMetalAPI: config.DefaultMetalAPIName,
+			wantName:     "default",
+		},
+		{
+			name:    "no metal-api selects the shoot",
+			region:  "region-a",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := *cfg
+			if !tt.withDefault {
+				cfg.MetalURL = ""
+			}
+
+			cluster := testCluster()
+			cluster.Shoot.Spec.Region = tt.region
+			if tt.cloudProfile != "" {
+				cluster.CloudProfile = &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: tt.cloudProfile}}
+			}
+
+			got, err := NewMetalResolver(&cfg, credentials.NewStore(cfg, nil, nil)).Resolve(context.Background(), cluster, "p1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.MetalAPI != tt.wantMetalAPI {
+				t.Errorf("metal-api = %q, want %q", got.MetalAPI, tt.wantMetalAPI)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("project name = %q, want %q", got.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+// writeClientCertificate writes a self-signed client certificate with the given common name and its key to the directory.
+func writeClientCertificate(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+
+	return certFile, keyFile
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(name, data, 0600); err != nil {
+		t.Fatalf("unable to write %s: %s", name, err)
+	}
+}
+
+func TestNewMetalClient(t *testing.T) {
+	var (
+		mu            sync.Mutex
+		authorization string
+		clientCN      string
+	)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		authorization = r.Header.Get("Authorization")
+		clientCN = ""
+		if len(r.TLS.PeerCertificates) > 0 {
+			clientCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	invalidCAFile := filepath.Join(dir, "invalid-ca.crt")
+	writeFile(t, invalidCAFile, []byte("invalid"))
+	tokenFile := filepath.Join(dir, "token")
+	// the token of a mounted file usually ends with a new line
+	writeFile(t, tokenFile, []byte("token\n"))
+	certFile, keyFile := writeClientCertificate(t, dir, "accounting")
+
+	tests := []struct {
+		name              string
+		auth              *config.MetalAPIAuth
+		wantCreateErr     bool
+		wantErr           bool
+		wantAuthorization string
+		wantClientCN      string
+	}{
+		{
+			name:              "bearer token",
+			auth:              &config.MetalAPIAuth{TokenFile: tokenFile, CAFile: caFile},
+			wantAuthorization: "Bearer token",
+		},
+		{
+			name:         "client certificate",
+			auth:         &config.MetalAPIAuth{ClientCertFile: certFile, ClientKeyFile: keyFile, CAFile: caFile},
+			wantClientCN: "accounting",
+		},
+		{
+			name:    "metal-api is not trusted without its ca",
+			auth:    &config.MetalAPIAuth{TokenFile: tokenFile},
+			wantErr: true,
+		},
+		{
+			name:          "missing token file",
+			auth:          &config.MetalAPIAuth{TokenFile: filepath.Join(dir, "missing")},
+			wantCreateErr: true,
+		},
+		{
+			name:          "invalid ca",
+			auth:          &config.MetalAPIAuth{CAFile: invalidCAFile},
+			wantCreateErr: true,
+		},
+		{
+			name:          "missing client key",
+			auth:          &config.MetalAPIAuth{ClientCertFile: certFile, ClientKeyFile: filepath.Join(dir, "missing")},
+			wantCreateErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mclient, err := newMetalClient(server.URL, "", "", tt.auth)
+			if (err != nil) != tt.wantCreateErr {
+				t.Fatalf("newMetalClient() error = %v, wantErr %t", err, tt.wantCreateErr)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = mclient.Project().ListProjects(project.NewListProjectsParams().WithContext(context.Background()), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListProjects() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if authorization != tt.wantAuthorization {
+				t.Errorf("authorization = %q, want %q", authorization, tt.wantAuthorization)
+			}
+			if clientCN != tt.wantClientCN {
+				t.Errorf("client certificate = %q, want %q", clientCN, tt.wantClientCN)
+			}
+		})
+	}
+}
+
+func TestProjectDimensions(t *testing.T) {
+	dimensions := []config.ProjectDimension{
+		{Key: "cost-center", Name: "cost_center"},
+		{Key: "contract", Name: "contract_id"},
+	}
+
+	tests := []struct {
+		name       string
+		dimensions []config.ProjectDimension
+		meta       *models.V1Meta
+		want       map[string]string
+	}{
+		{
+			name:       "labels of the project",
+			dimensions: dimensions,
+			meta:       &models.V1Meta{Labels: []string{"cost-center=1234", "contract=c-1", "other=value"}},
+			want:       map[string]string{"cost_center": "1234", "contract_id": "c-1"},
+		},
+		{
+			name:       "annotations take precedence over labels",
+			dimensions: dimensions,
+			meta: &models.V1Meta{
+				Labels:      []string{"cost-center=1234"},
+				Annotations: map[string]string{"cost-center": "5678"},
+			},
+			want: map[string]string{"cost_center": "5678"},
+		},
+		{
+			name:       "label without value",
+			dimensions: dimensions,
+			meta:       &models.V1Meta{Labels: []string{"contract"}},
+			want:       map[string]string{"contract_id": ""},
+		},
+		{
+			name:       "project without the dimensions",
+			dimensions: dimensions,
+			meta:       &models.V1Meta{Labels: []string{"other=value"}},
+		},
+		{
+			name:       "project without meta",
+			dimensions: dimensions,
+		},
+		{
+			name: "no dimensions configured",
+			meta: &models.V1Meta{Labels: []string{"cost-center=1234"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, projectDimensions(tt.dimensions, tt.meta)); diff != "" {
+				t.Errorf("projectDimensions() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
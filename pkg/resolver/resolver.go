@@ -0,0 +1,114 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/credentials"
+	"github.com/fi-ts/gardener-extension-accounting/pkg/identity"
+)
+
+// Project contains the metadata of a shoot's project which is passed to the accounting-exporter.
+type Project struct {
+	// ID is the id of the project
+	ID string
+	// Name is the name of the project
+	Name string
+	// TenantID is the id of the tenant the project belongs to
+	TenantID string
+	// MetalAPI is the name of the metal-api the project was resolved from, it is empty for the other project resolvers
+	MetalAPI string
+	// Dimensions are the additional accounting dimensions of the project by their name, only set by the metal project resolver
+	Dimensions map[string]string
+}
+
+// ProjectResolver looks up the project metadata of a shoot.
+type ProjectResolver interface {
+	// Resolve returns the project metadata for the given project id of the cluster.
+	Resolve(ctx context.Context, cluster *controller.Cluster, projectID string) (*Project, error)
+}
+
+// New returns the project resolver configured in the accounting configuration, which picks the project resolver by the provider type of the shoot.
+// The garden client is only used by the garden project resolver, the credentials only by the metal project resolver.
+func New(cfg *config.Accounting, gardenClient client.Client, credentials *credentials.Store) (ProjectResolver, error) {
+	resolvers := map[config.ProjectResolverType]ProjectResolver{}
+
+	// every type is only instantiated once, such that the shoots of all provider types share the project caches
+	resolverOfType := func(resolverType config.ProjectResolverType) (ProjectResolver, error) {
+		if r, ok := resolvers[resolverType]; ok {
+			return r, nil
+		}
+
+		var r ProjectResolver
+		switch resolverType {
+		case config.ProjectResolverTypeMetal:
+			r = NewMetalResolver(cfg, credentials)
+		case config.ProjectResolverTypeGarden:
+			r = NewGardenResolver(gardenClient)
+		case config.ProjectResolverTypeStatic:
+			r = NewStaticResolver(cfg.ProjectResolver.Static)
+		default:
+			return nil, fmt.Errorf("unsupported project resolver type: %q", resolverType)
+		}
+
+		resolvers[resolverType] = r
+		return r, nil
+	}
+
+	defaultResolver, err := resolverOfType(cfg.ProjectResolver.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &providerResolver{
+		defaultResolver: defaultResolver,
+		providerTypes:   map[string]ProjectResolver{},
+	}
+
+	for providerType, resolverType := range cfg.ProjectResolver.ProviderTypes {
+		r.providerTypes[providerType], err = resolverOfType(resolverType)
+		if err != nil {
+			return nil, fmt.Errorf("project resolver of provider type %q: %w", providerType, err)
+		}
+	}
+
+	// the metal-api only knows the projects of the metal shoots
+	if cfg.ProjectResolver.Type == config.ProjectResolverTypeMetal {
+		r.fallbackResolver, err = resolverOfType(config.ProjectResolverTypeGarden)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// providerResolver resolves the projects with the project resolver of the provider type of the shoot.
+type providerResolver struct {
+	defaultResolver ProjectResolver
+	// fallbackResolver resolves the shoots of the provider types other than metal, if the default project resolver is the metal one
+	fallbackResolver ProjectResolver
+	providerTypes    map[string]ProjectResolver
+}
+
+// Resolve implements ProjectResolver.
+func (r *providerResolver) Resolve(ctx context.Context, cluster *controller.Cluster, projectID string) (*Project, error) {
+	return r.resolverFor(cluster.Shoot.Spec.Provider.Type).Resolve(ctx, cluster, projectID)
+}
+
+// resolverFor returns the project resolver of the shoots of the given provider type.
+func (r *providerResolver) resolverFor(providerType string) ProjectResolver {
+	if resolver, ok := r.providerTypes[providerType]; ok {
+		return resolver
+	}
+
+	if r.fallbackResolver != nil && providerType != identity.ProviderTypeMetal {
+		return r.fallbackResolver
+	}
+
+	return r.defaultResolver
+}
@@ -0,0 +1,265 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/metal-stack/metal-lib/pkg/pointer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+func testCluster() *controller.Cluster {
+	return &controller.Cluster{
+		Shoot: &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "garden-test"},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		resolver      config.ProjectResolver
+		providerTypes map[string]ProjectResolver
+		wantErr       bool
+	}{
+		{
+			name:     "metal resolves the metal shoots, the other shoots fall back to garden",
+			resolver: config.ProjectResolver{Type: config.ProjectResolverTypeMetal},
+			providerTypes: map[string]ProjectResolver{
+				"metal":     &metalResolver{},
+				"local":     &gardenResolver{},
+				"openstack": &gardenResolver{},
+			},
+		},
+		{
+			name: "resolvers per provider type",
+			resolver: config.ProjectResolver{
+				Type:          config.ProjectResolverTypeMetal,
+				ProviderTypes: map[string]config.ProjectResolverType{"openstack": config.ProjectResolverTypeStatic},
+			},
+			providerTypes: map[string]ProjectResolver{
+				"metal":     &metalResolver{},
+				"local":     &gardenResolver{},
+				"openstack": &staticResolver{},
+			},
+		},
+		{
+			name: "garden resolves all shoots",
+			resolver: config.ProjectResolver{
+				Type: config.ProjectResolverTypeGarden,
+			},
+			providerTypes: map[string]ProjectResolver{
+				"metal": &gardenResolver{},
+				"local": &gardenResolver{},
+			},
+		},
+		{
+			name: "static resolves all shoots except the configured provider types",
+			resolver: config.ProjectResolver{
+				Type:          config.ProjectResolverTypeStatic,
+				ProviderTypes: map[string]config.ProjectResolverType{"metal": config.ProjectResolverTypeMetal},
+			},
+			providerTypes: map[string]ProjectResolver{
+				"metal": &metalResolver{},
+				"local": &staticResolver{},
+			},
+		},
+		{
+			name:     "unsupported",
+			resolver: config.ProjectResolver{Type: "ldap"},
+			wantErr:  true,
+		},
+		{
+			name: "unsupported for a provider type",
+			resolver: config.ProjectResolver{
+				Type:          config.ProjectResolverTypeGarden,
+				ProviderTypes: map[string]config.ProjectResolverType{"local": "ldap"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Accounting{
+				ProjectCacheTTL: &metav1.Duration{Duration: time.Minute},
+				ProjectResolver: tt.resolver,
+			}
+
+			r, err := New(cfg, nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for providerType, want := range tt.providerTypes {
+				if got := r.(*providerResolver).resolverFor(providerType); reflect.TypeOf(got) != reflect.TypeOf(want) {
+					t.Errorf("resolver of provider type %q = %T, want %T", providerType, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderResolver(t *testing.T) {
+	r, err := New(&config.Accounting{
+		ProjectResolver: config.ProjectResolver{
+			Type:          config.ProjectResolverTypeStatic,
+			Static:        map[string]config.StaticProject{"p1": {Name: "project", TenantID: "tenant"}},
+			ProviderTypes: map[string]config.ProjectResolverType{"local": config.ProjectResolverTypeStatic},
+		},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %s", err)
+	}
+
+	cluster := testCluster()
+	cluster.Shoot.Spec.Provider.Type = "local"
+
+	got, err := r.Resolve(context.Background(), cluster, "p1")
+	if err != nil {
+		t.Fatalf("Resolve() error = %s", err)
+	}
+	if diff := cmp.Diff(&Project{ID: "p1", Name: "project", TenantID: "tenant"}, got); diff != "" {
+		t.Errorf("Resolve() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestStaticResolver(t *testing.T) {
+	r := NewStaticResolver(map[string]config.StaticProject{
+		"p1": {Name: "project", TenantID: "tenant"},
+	})
+
+	tests := []struct {
+		name      string
+		projectID string
+		want      *Project
+		wantErr   bool
+	}{
+		{
+			name:      "known project",
+			projectID: "p1",
+			want:      &Project{ID: "p1", Name: "project", TenantID: "tenant"},
+		},
+		{
+			name:      "unknown project",
+			projectID: "p2",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), testCluster(), tt.projectID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Resolve() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGardenResolver(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := gardencorev1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to create scheme: %s", err)
+	}
+
+	project := func(annotations map[string]string) *gardencorev1beta1.Project {
+		return &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: annotations},
+			Spec:       gardencorev1beta1.ProjectSpec{Namespace: pointer.Pointer("garden-test")},
+		}
+	}
+	shoot := func(annotations map[string]string) *gardencorev1beta1.Shoot {
+		return &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "garden-test", Annotations: annotations},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    *Project
+		wantErr bool
+	}{
+		{
+			name: "tenant and name of the project",
+			objects: []client.Object{
+				project(map[string]string{TenantAnnotation: "tenant", ProjectNameAnnotation: "project"}),
+				shoot(nil),
+			},
+			want: &Project{ID: "p1", Name: "project", TenantID: "tenant"},
+		},
+		{
+			name: "shoot annotations take precedence",
+			objects: []client.Object{
+				project(map[string]string{TenantAnnotation: "tenant", ProjectNameAnnotation: "project"}),
+				shoot(map[string]string{TenantAnnotation: "other-tenant", ProjectNameAnnotation: "other-project"}),
+			},
+			want: &Project{ID: "p1", Name: "other-project", TenantID: "other-tenant"},
+		},
+		{
+			name: "name of the gardener project as fallback",
+			objects: []client.Object{
+				project(map[string]string{TenantAnnotation: "tenant"}),
+				shoot(nil),
+			},
+			want: &Project{ID: "p1", Name: "test", TenantID: "tenant"},
+		},
+		{
+			name: "missing tenant",
+			objects: []client.Object{
+				project(nil),
+				shoot(nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing project",
+			objects: []client.Object{
+				shoot(map[string]string{TenantAnnotation: "tenant"}),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				WithIndex(&gardencorev1beta1.Project{}, core.ProjectNamespace, func(obj client.Object) []string {
+					if namespace := obj.(*gardencorev1beta1.Project).Spec.Namespace; namespace != nil {
+						return []string{*namespace}
+					}
+					return nil
+				}).
+				Build()
+
+			got, err := NewGardenResolver(c).Resolve(context.Background(), testCluster(), "p1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Resolve() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+
+	"github.com/fi-ts/gardener-extension-accounting/pkg/apis/config"
+)
+
+type staticResolver struct {
+	projects map[string]config.StaticProject
+}
+
+// NewStaticResolver returns a project resolver which reads the project metadata from a static mapping.
+func NewStaticResolver(projects map[string]config.StaticProject) ProjectResolver {
+	return &staticResolver{
+		projects: projects,
+	}
+}
+
+// Resolve implements ProjectResolver.
+func (r *staticResolver) Resolve(_ context.Context, _ *controller.Cluster, projectID string) (*Project, error) {
+	p, ok := r.projects[projectID]
+	if !ok {
+		return nil, fmt.Errorf("project %q is not contained in the static project mapping", projectID)
+	}
+
+	return &Project{
+		ID:       projectID,
+		Name:     p.Name,
+		TenantID: p.TenantID,
+	}, nil
+}